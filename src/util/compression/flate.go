@@ -0,0 +1,71 @@
+package compression
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+)
+
+type Flate struct{}
+
+var FlateApp Flate
+
+func (*Flate) New() *Flate { return &Flate{} }
+
+//go:fix 推荐使用New方法
+func NewFlate() *Flate { return &Flate{} }
+
+// Compress 压缩
+func (*Flate) Compress(originalData []byte) ([]byte, error) {
+	var (
+		err    error
+		buffer bytes.Buffer
+		writer *flate.Writer
+	)
+
+	// 创建一个新的Flate压缩器
+	writer, _ = flate.NewWriter(&buffer, flate.DefaultCompression)
+
+	// 写入数据到压缩器
+	if _, err = writer.Write(originalData); err != nil {
+		return nil, err
+	}
+
+	// 记住要关闭Writer以完成压缩
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	// 压缩后的数据存储在b的缓冲区中
+	return buffer.Bytes(), nil
+}
+
+// Decompress 解压缩
+func (*Flate) Decompress(data []byte) ([]byte, error) {
+	var (
+		err    error
+		buffer bytes.Buffer
+		reader io.ReadCloser
+	)
+	reader = flate.NewReader(bytes.NewReader(data))
+	defer reader.Close()
+
+	// 读取解压缩后的数据到缓冲区
+	if _, err = io.Copy(&buffer, reader); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// NewReader 流式解压缩：调用方负责关闭
+func (*Flate) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	return flate.NewReader(reader), nil
+}
+
+// NewWriter 流式压缩：关闭后才写入完整的压缩数据
+func (*Flate) NewWriter(writer io.Writer) io.WriteCloser {
+	w, _ := flate.NewWriter(writer, flate.DefaultCompression)
+
+	return w
+}
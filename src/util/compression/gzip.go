@@ -0,0 +1,77 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+type Gzip struct{}
+
+var GzipApp Gzip
+
+func (*Gzip) New() *Gzip { return &Gzip{} }
+
+//go:fix 推荐使用New方法
+func NewGzip() *Gzip { return &Gzip{} }
+
+// Compress 压缩
+func (*Gzip) Compress(originalData []byte) ([]byte, error) {
+	var (
+		err    error
+		buffer bytes.Buffer
+		writer *gzip.Writer
+	)
+
+	// 创建一个新的Gzip压缩器
+	writer = gzip.NewWriter(&buffer)
+
+	// 写入数据到压缩器
+	if _, err = writer.Write(originalData); err != nil {
+		return nil, err
+	}
+
+	// 记住要关闭Writer以完成压缩
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	// 压缩后的数据存储在b的缓冲区中
+	return buffer.Bytes(), nil
+}
+
+// Decompress 解压缩
+func (*Gzip) Decompress(data []byte) ([]byte, error) {
+	var (
+		err    error
+		buffer bytes.Buffer
+		reader io.ReadCloser
+	)
+	reader, err = gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// 读取解压缩后的数据到缓冲区
+	if _, err = io.Copy(&buffer, reader); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// NewReader 流式解压缩：调用方负责关闭
+func (*Gzip) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	r, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewWriter 流式压缩：关闭后才写入完整的压缩数据
+func (*Gzip) NewWriter(writer io.Writer) io.WriteCloser {
+	return gzip.NewWriter(writer)
+}
@@ -60,3 +60,18 @@ func (*Zlib) Decompress(data []byte) ([]byte, error) {
 
 	return buffer.Bytes(), nil
 }
+
+// NewReader 流式解压缩：调用方负责关闭
+func (*Zlib) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	r, err := zlib.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewWriter 流式压缩：关闭后才写入完整的压缩数据
+func (*Zlib) NewWriter(writer io.Writer) io.WriteCloser {
+	return zlib.NewWriter(writer)
+}
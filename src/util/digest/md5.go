@@ -3,6 +3,7 @@ package digest
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"io"
 )
 
 // Md5 编码
@@ -14,3 +15,13 @@ func Md5(original []byte) (string, error) {
 
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
+
+// Md5Reader 编码：流式读取
+func Md5Reader(reader io.Reader) (string, error) {
+	hash := md5.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -3,6 +3,7 @@ package digest
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"io"
 )
 
 // Sha256 摘要算法
@@ -16,3 +17,13 @@ func Sha256(original []byte) (string, error) {
 
 	return shaString, nil
 }
+
+// Sha256Reader 摘要算法：流式读取
+func Sha256Reader(reader io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/tjfoc/gmsm/sm3"
 
 	"encoding/hex"
+	"io"
 )
 
 // Sm3 生成sm3摘要
@@ -15,3 +16,13 @@ func Sm3(original []byte) string {
 
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// Sm3Reader 生成sm3摘要：流式读取
+func Sm3Reader(reader io.Reader) (string, error) {
+	hash := sm3.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 
-	"github.com/jericho-yu/nova/src/util/filesystem"
-
 	"gopkg.in/yaml.v2"
 )
 
@@ -25,11 +24,14 @@ func (*HonestMan) New(dirs ...string) *HonestMan {
 }
 
 func (*HonestMan) NewByAbsolute(dirs ...string) *HonestMan {
-	return &HonestMan{dir: filesystem.FileSystemApp.NewByAbsolute(dirs[0]).Joins(dirs[1:]...).GetDir()}
+	return &HonestMan{dir: filepath.Join(dirs...)}
 }
 
+// NewByRelative 相对于当前工作目录：不依赖filesystem包，以便httpClient等被filesystem依赖的包使用
 func (*HonestMan) NewByRelative(dirs ...string) *HonestMan {
-	return &HonestMan{dir: filesystem.FileSystemApp.NewByRelative(".").Joins(dirs...).GetDir()}
+	rootPath, _ := filepath.Abs(".")
+
+	return &HonestMan{dir: filepath.Join(append([]string{rootPath}, dirs...)...)}
 }
 
 // Error 获取错误
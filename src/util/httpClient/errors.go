@@ -10,31 +10,73 @@ import (
 )
 
 type (
-	ReadResponseError    struct{ myError.MyError }
-	UrlEmptyError        struct{ myError.MyError }
-	GenerateCertError    struct{ myError.MyError }
-	GenerateRequestError struct{ myError.MyError }
-	UnmarshalXmlError    struct{ myError.MyError }
-	UnmarshalJsonError   struct{ myError.MyError }
-	SetSteamBodyError    struct{ myError.MyError }
-	SetFormBodyError     struct{ myError.MyError }
-	SetXmlBodyError      struct{ myError.MyError }
-	SetJsonBodyError     struct{ myError.MyError }
-	WriteResponseError   struct{ myError.MyError }
+	ReadResponseError      struct{ myError.MyError }
+	UrlEmptyError          struct{ myError.MyError }
+	GenerateCertError      struct{ myError.MyError }
+	GenerateRequestError   struct{ myError.MyError }
+	UnmarshalXmlError      struct{ myError.MyError }
+	UnmarshalJsonError     struct{ myError.MyError }
+	SetSteamBodyError      struct{ myError.MyError }
+	SetFormBodyError       struct{ myError.MyError }
+	SetXmlBodyError        struct{ myError.MyError }
+	SetJsonBodyError       struct{ myError.MyError }
+	WriteResponseError     struct{ myError.MyError }
+	InterceptError         struct{ myError.MyError }
+	RequestCanceledError   struct{ myError.MyError }
+	RequestTimeoutError    struct{ myError.MyError }
+	TransportNotFoundError struct{ myError.MyError }
+	SetTransportError      struct{ myError.MyError }
+	CertPinError           struct{ myError.MyError }
+	StreamStopError        struct{ myError.MyError }
+	DownloadError          struct{ myError.MyError }
+	ChecksumMismatchError  struct{ myError.MyError }
+	CassetteNotMatchError  struct{ myError.MyError }
+	CassetteError          struct{ myError.MyError }
+	ResponseStatusError    struct{ myError.MyError }
+	SessionError           struct{ myError.MyError }
+	TokenError             struct{ myError.MyError }
+	CircuitOpenError       struct{ myError.MyError }
+	RateLimitError         struct{ myError.MyError }
+	HarError               struct{ myError.MyError }
+	CompressError          struct{ myError.MyError }
+	DecompressError        struct{ myError.MyError }
+	QueryError             struct{ myError.MyError }
+	MockExpectationError   struct{ myError.MyError }
 )
 
 var (
-	ReadResponseErr    ReadResponseError
-	UrlEmptyErr        UrlEmptyError
-	GenerateCertErr    GenerateCertError
-	GenerateRequestErr GenerateRequestError
-	UnmarshalXmlErr    UnmarshalXmlError
-	UnmarshalJsonErr   UnmarshalJsonError
-	SetSteamBodyErr    SetSteamBodyError
-	SetFormBodyErr     SetFormBodyError
-	SetXmlBodyErr      SetXmlBodyError
-	SetJsonBodyErr     SetJsonBodyError
-	WriteResponseErr   WriteResponseError
+	ReadResponseErr      ReadResponseError
+	UrlEmptyErr          UrlEmptyError
+	GenerateCertErr      GenerateCertError
+	GenerateRequestErr   GenerateRequestError
+	UnmarshalXmlErr      UnmarshalXmlError
+	UnmarshalJsonErr     UnmarshalJsonError
+	SetSteamBodyErr      SetSteamBodyError
+	SetFormBodyErr       SetFormBodyError
+	SetXmlBodyErr        SetXmlBodyError
+	SetJsonBodyErr       SetJsonBodyError
+	WriteResponseErr     WriteResponseError
+	InterceptErr         InterceptError
+	RequestCanceledErr   RequestCanceledError
+	RequestTimeoutErr    RequestTimeoutError
+	TransportNotFoundErr TransportNotFoundError
+	SetTransportErr      SetTransportError
+	CertPinErr           CertPinError
+	StreamStopErr        StreamStopError
+	DownloadErr          DownloadError
+	ChecksumMismatchErr  ChecksumMismatchError
+	CassetteNotMatchErr  CassetteNotMatchError
+	CassetteErr          CassetteError
+	ResponseStatusErr    ResponseStatusError
+	SessionErr           SessionError
+	TokenErr             TokenError
+	CircuitOpenErr       CircuitOpenError
+	RateLimitErr         RateLimitError
+	HarErr               HarError
+	CompressErr          CompressError
+	DecompressErr        DecompressError
+	QueryErr             QueryError
+	MockExpectationErr   MockExpectationError
 )
 
 func (*ReadResponseError) New(msg string) myError.IMyError {
@@ -228,3 +270,355 @@ func (my *WriteResponseError) Error() string { return my.MyError.Msg }
 func (my *WriteResponseError) Is(target error) bool {
 	return reflect.DeepEqual(target, &WriteResponseErr)
 }
+
+func (*InterceptError) New(msg string) myError.IMyError {
+	return &InterceptError{MyError: myError.MyError{Msg: array.New([]string{"拦截器中断请求", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*InterceptError) Wrap(err error) myError.IMyError {
+	return &InterceptError{MyError: myError.MyError{Msg: fmt.Errorf("拦截器中断请求"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*InterceptError) Panic() myError.IMyError {
+	return &InterceptError{MyError: myError.MyError{Msg: "拦截器中断请求"}}
+}
+
+func (my *InterceptError) Error() string { return my.MyError.Msg }
+
+func (my *InterceptError) Is(target error) bool { return reflect.DeepEqual(target, &InterceptErr) }
+
+func (*RequestCanceledError) New(msg string) myError.IMyError {
+	return &RequestCanceledError{MyError: myError.MyError{Msg: array.New([]string{"请求已取消", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*RequestCanceledError) Wrap(err error) myError.IMyError {
+	return &RequestCanceledError{MyError: myError.MyError{Msg: fmt.Errorf("请求已取消"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RequestCanceledError) Panic() myError.IMyError {
+	return &RequestCanceledError{MyError: myError.MyError{Msg: "请求已取消"}}
+}
+
+func (my *RequestCanceledError) Error() string { return my.MyError.Msg }
+
+func (my *RequestCanceledError) Is(target error) bool {
+	return reflect.DeepEqual(target, &RequestCanceledErr)
+}
+
+func (*RequestTimeoutError) New(msg string) myError.IMyError {
+	return &RequestTimeoutError{MyError: myError.MyError{Msg: array.New([]string{"请求超时", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*RequestTimeoutError) Wrap(err error) myError.IMyError {
+	return &RequestTimeoutError{MyError: myError.MyError{Msg: fmt.Errorf("请求超时"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RequestTimeoutError) Panic() myError.IMyError {
+	return &RequestTimeoutError{MyError: myError.MyError{Msg: "请求超时"}}
+}
+
+func (my *RequestTimeoutError) Error() string { return my.MyError.Msg }
+
+func (my *RequestTimeoutError) Is(target error) bool {
+	return reflect.DeepEqual(target, &RequestTimeoutErr)
+}
+
+func (*TransportNotFoundError) New(msg string) myError.IMyError {
+	return &TransportNotFoundError{MyError: myError.MyError{Msg: array.New([]string{"传输层配置不存在", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*TransportNotFoundError) Wrap(err error) myError.IMyError {
+	return &TransportNotFoundError{MyError: myError.MyError{Msg: fmt.Errorf("传输层配置不存在"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*TransportNotFoundError) Panic() myError.IMyError {
+	return &TransportNotFoundError{MyError: myError.MyError{Msg: "传输层配置不存在"}}
+}
+
+func (my *TransportNotFoundError) Error() string { return my.MyError.Msg }
+
+func (my *TransportNotFoundError) Is(target error) bool {
+	return reflect.DeepEqual(target, &TransportNotFoundErr)
+}
+
+func (*SetTransportError) New(msg string) myError.IMyError {
+	return &SetTransportError{MyError: myError.MyError{Msg: array.New([]string{"设置传输层失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*SetTransportError) Wrap(err error) myError.IMyError {
+	return &SetTransportError{MyError: myError.MyError{Msg: fmt.Errorf("设置传输层失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*SetTransportError) Panic() myError.IMyError {
+	return &SetTransportError{MyError: myError.MyError{Msg: "设置传输层失败"}}
+}
+
+func (my *SetTransportError) Error() string { return my.MyError.Msg }
+
+func (my *SetTransportError) Is(target error) bool {
+	return reflect.DeepEqual(target, &SetTransportErr)
+}
+
+func (*CertPinError) New(msg string) myError.IMyError {
+	return &CertPinError{MyError: myError.MyError{Msg: array.New([]string{"证书指纹校验失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*CertPinError) Wrap(err error) myError.IMyError {
+	return &CertPinError{MyError: myError.MyError{Msg: fmt.Errorf("证书指纹校验失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*CertPinError) Panic() myError.IMyError {
+	return &CertPinError{MyError: myError.MyError{Msg: "证书指纹校验失败"}}
+}
+
+func (my *CertPinError) Error() string { return my.MyError.Msg }
+
+func (my *CertPinError) Is(target error) bool { return reflect.DeepEqual(target, &CertPinErr) }
+
+func (*StreamStopError) New(msg string) myError.IMyError {
+	return &StreamStopError{MyError: myError.MyError{Msg: array.New([]string{"停止读取响应流", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*StreamStopError) Wrap(err error) myError.IMyError {
+	return &StreamStopError{MyError: myError.MyError{Msg: fmt.Errorf("停止读取响应流"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*StreamStopError) Panic() myError.IMyError {
+	return &StreamStopError{MyError: myError.MyError{Msg: "停止读取响应流"}}
+}
+
+func (my *StreamStopError) Error() string { return my.MyError.Msg }
+
+func (my *StreamStopError) Is(target error) bool { return reflect.DeepEqual(target, &StreamStopErr) }
+
+func (*DownloadError) New(msg string) myError.IMyError {
+	return &DownloadError{MyError: myError.MyError{Msg: array.New([]string{"下载失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*DownloadError) Wrap(err error) myError.IMyError {
+	return &DownloadError{MyError: myError.MyError{Msg: fmt.Errorf("下载失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*DownloadError) Panic() myError.IMyError {
+	return &DownloadError{MyError: myError.MyError{Msg: "下载失败"}}
+}
+
+func (my *DownloadError) Error() string { return my.MyError.Msg }
+
+func (my *DownloadError) Is(target error) bool { return reflect.DeepEqual(target, &DownloadErr) }
+
+func (*ChecksumMismatchError) New(msg string) myError.IMyError {
+	return &ChecksumMismatchError{MyError: myError.MyError{Msg: array.New([]string{"文件校验失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*ChecksumMismatchError) Wrap(err error) myError.IMyError {
+	return &ChecksumMismatchError{MyError: myError.MyError{Msg: fmt.Errorf("文件校验失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*ChecksumMismatchError) Panic() myError.IMyError {
+	return &ChecksumMismatchError{MyError: myError.MyError{Msg: "文件校验失败"}}
+}
+
+func (my *ChecksumMismatchError) Error() string { return my.MyError.Msg }
+
+func (my *ChecksumMismatchError) Is(target error) bool {
+	return reflect.DeepEqual(target, &ChecksumMismatchErr)
+}
+
+func (*CassetteNotMatchError) New(msg string) myError.IMyError {
+	return &CassetteNotMatchError{MyError: myError.MyError{Msg: array.New([]string{"未找到匹配的录制记录", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*CassetteNotMatchError) Wrap(err error) myError.IMyError {
+	return &CassetteNotMatchError{MyError: myError.MyError{Msg: fmt.Errorf("未找到匹配的录制记录"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*CassetteNotMatchError) Panic() myError.IMyError {
+	return &CassetteNotMatchError{MyError: myError.MyError{Msg: "未找到匹配的录制记录"}}
+}
+
+func (my *CassetteNotMatchError) Error() string { return my.MyError.Msg }
+
+func (my *CassetteNotMatchError) Is(target error) bool {
+	return reflect.DeepEqual(target, &CassetteNotMatchErr)
+}
+
+func (*CassetteError) New(msg string) myError.IMyError {
+	return &CassetteError{MyError: myError.MyError{Msg: array.New([]string{"录制文件读写失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*CassetteError) Wrap(err error) myError.IMyError {
+	return &CassetteError{MyError: myError.MyError{Msg: fmt.Errorf("录制文件读写失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*CassetteError) Panic() myError.IMyError {
+	return &CassetteError{MyError: myError.MyError{Msg: "录制文件读写失败"}}
+}
+
+func (my *CassetteError) Error() string { return my.MyError.Msg }
+
+func (my *CassetteError) Is(target error) bool { return reflect.DeepEqual(target, &CassetteErr) }
+
+func (*ResponseStatusError) New(msg string) myError.IMyError {
+	return &ResponseStatusError{MyError: myError.MyError{Msg: array.New([]string{"响应状态码错误", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*ResponseStatusError) Wrap(err error) myError.IMyError {
+	return &ResponseStatusError{MyError: myError.MyError{Msg: fmt.Errorf("响应状态码错误"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*ResponseStatusError) Panic() myError.IMyError {
+	return &ResponseStatusError{MyError: myError.MyError{Msg: "响应状态码错误"}}
+}
+
+func (my *ResponseStatusError) Error() string { return my.MyError.Msg }
+
+func (my *ResponseStatusError) Is(target error) bool {
+	return reflect.DeepEqual(target, &ResponseStatusErr)
+}
+
+func (*SessionError) New(msg string) myError.IMyError {
+	return &SessionError{MyError: myError.MyError{Msg: array.New([]string{"会话持久化失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*SessionError) Wrap(err error) myError.IMyError {
+	return &SessionError{MyError: myError.MyError{Msg: fmt.Errorf("会话持久化失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*SessionError) Panic() myError.IMyError {
+	return &SessionError{MyError: myError.MyError{Msg: "会话持久化失败"}}
+}
+
+func (my *SessionError) Error() string { return my.MyError.Msg }
+
+func (my *SessionError) Is(target error) bool { return reflect.DeepEqual(target, &SessionErr) }
+
+func (*TokenError) New(msg string) myError.IMyError {
+	return &TokenError{MyError: myError.MyError{Msg: array.New([]string{"获取令牌失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*TokenError) Wrap(err error) myError.IMyError {
+	return &TokenError{MyError: myError.MyError{Msg: fmt.Errorf("获取令牌失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*TokenError) Panic() myError.IMyError {
+	return &TokenError{MyError: myError.MyError{Msg: "获取令牌失败"}}
+}
+
+func (my *TokenError) Error() string { return my.MyError.Msg }
+
+func (my *TokenError) Is(target error) bool { return reflect.DeepEqual(target, &TokenErr) }
+
+func (*CircuitOpenError) New(msg string) myError.IMyError {
+	return &CircuitOpenError{MyError: myError.MyError{Msg: array.New([]string{"熔断器已打开", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*CircuitOpenError) Wrap(err error) myError.IMyError {
+	return &CircuitOpenError{MyError: myError.MyError{Msg: fmt.Errorf("熔断器已打开"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*CircuitOpenError) Panic() myError.IMyError {
+	return &CircuitOpenError{MyError: myError.MyError{Msg: "熔断器已打开"}}
+}
+
+func (my *CircuitOpenError) Error() string { return my.MyError.Msg }
+
+func (my *CircuitOpenError) Is(target error) bool { return reflect.DeepEqual(target, &CircuitOpenErr) }
+
+func (*RateLimitError) New(msg string) myError.IMyError {
+	return &RateLimitError{MyError: myError.MyError{Msg: array.New([]string{"超出请求频率限制", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*RateLimitError) Wrap(err error) myError.IMyError {
+	return &RateLimitError{MyError: myError.MyError{Msg: fmt.Errorf("超出请求频率限制"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RateLimitError) Panic() myError.IMyError {
+	return &RateLimitError{MyError: myError.MyError{Msg: "超出请求频率限制"}}
+}
+
+func (my *RateLimitError) Error() string { return my.MyError.Msg }
+
+func (my *RateLimitError) Is(target error) bool { return reflect.DeepEqual(target, &RateLimitErr) }
+
+func (*HarError) New(msg string) myError.IMyError {
+	return &HarError{MyError: myError.MyError{Msg: array.New([]string{"HAR日志写入失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*HarError) Wrap(err error) myError.IMyError {
+	return &HarError{MyError: myError.MyError{Msg: fmt.Errorf("HAR日志写入失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*HarError) Panic() myError.IMyError {
+	return &HarError{MyError: myError.MyError{Msg: "HAR日志写入失败"}}
+}
+
+func (my *HarError) Error() string { return my.MyError.Msg }
+
+func (my *HarError) Is(target error) bool { return reflect.DeepEqual(target, &HarErr) }
+
+func (*CompressError) New(msg string) myError.IMyError {
+	return &CompressError{MyError: myError.MyError{Msg: array.New([]string{"压缩请求体失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*CompressError) Wrap(err error) myError.IMyError {
+	return &CompressError{MyError: myError.MyError{Msg: fmt.Errorf("压缩请求体失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*CompressError) Panic() myError.IMyError {
+	return &CompressError{MyError: myError.MyError{Msg: "压缩请求体失败"}}
+}
+
+func (my *CompressError) Error() string { return my.MyError.Msg }
+
+func (my *CompressError) Is(target error) bool { return reflect.DeepEqual(target, &CompressErr) }
+
+func (*DecompressError) New(msg string) myError.IMyError {
+	return &DecompressError{MyError: myError.MyError{Msg: array.New([]string{"解压响应体失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*DecompressError) Wrap(err error) myError.IMyError {
+	return &DecompressError{MyError: myError.MyError{Msg: fmt.Errorf("解压响应体失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*DecompressError) Panic() myError.IMyError {
+	return &DecompressError{MyError: myError.MyError{Msg: "解压响应体失败"}}
+}
+
+func (my *DecompressError) Error() string { return my.MyError.Msg }
+
+func (my *DecompressError) Is(target error) bool { return reflect.DeepEqual(target, &DecompressErr) }
+
+func (*QueryError) New(msg string) myError.IMyError {
+	return &QueryError{MyError: myError.MyError{Msg: array.New([]string{"查询参数编码失败", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*QueryError) Wrap(err error) myError.IMyError {
+	return &QueryError{MyError: myError.MyError{Msg: fmt.Errorf("查询参数编码失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*QueryError) Panic() myError.IMyError {
+	return &QueryError{MyError: myError.MyError{Msg: "查询参数编码失败"}}
+}
+
+func (my *QueryError) Error() string { return my.MyError.Msg }
+
+func (my *QueryError) Is(target error) bool { return reflect.DeepEqual(target, &QueryErr) }
+
+func (*MockExpectationError) New(msg string) myError.IMyError {
+	return &MockExpectationError{MyError: myError.MyError{Msg: array.New([]string{"模拟服务期望未满足", msg}).JoinWithoutEmpty("：")}}
+}
+
+func (*MockExpectationError) Wrap(err error) myError.IMyError {
+	return &MockExpectationError{MyError: myError.MyError{Msg: fmt.Errorf("模拟服务期望未满足"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*MockExpectationError) Panic() myError.IMyError {
+	return &MockExpectationError{MyError: myError.MyError{Msg: "模拟服务期望未满足"}}
+}
+
+func (my *MockExpectationError) Error() string { return my.MyError.Msg }
+
+func (my *MockExpectationError) Is(target error) bool {
+	return reflect.DeepEqual(target, &MockExpectationErr)
+}
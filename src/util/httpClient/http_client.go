@@ -2,8 +2,8 @@ package httpClient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -13,10 +13,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/jericho-yu/nova/src/util/str"
-
 	jsonIter "github.com/json-iterator/go"
 )
 
@@ -25,18 +25,41 @@ type (
 	HttpClient struct {
 		Err                error
 		requestUrl         string
-		requestQueries     map[string]string
+		requestQueries     url.Values
 		requestMethod      string
 		requestBody        []byte
+		requestBodyStream  *bodyStream
+		requestEncoding    ContentEncoding
+		acceptEncodings    []ContentEncoding
 		requestHeaders     map[string][]string
+		defaultHeaders     map[string][]string
 		request            *http.Request
 		response           *http.Response
 		responseBody       []byte
 		responseBodyBuffer *bytes.Buffer
 		isReady            bool
 		cert               []byte
-		transport          *http.Transport
-		timeoutSecond      int64
+		transport          http.RoundTripper
+		transportName      string
+		tlsConfig          *tls.Config
+		tlsTransport       *clientTLSTransport
+		clientCerts        []tls.Certificate
+		pinnedSpkiHashes   []string
+		minTLSVersion      uint16
+		insecureSkipVerify bool
+		timeout            time.Duration
+		ctx                context.Context
+		interceptors       []Interceptor
+		retryPolicy        *RetryPolicy
+		retryHistory       []RetryAttempt
+		cookieJar          http.CookieJar
+		tokenSource        TokenSource
+		circuitBreaker     *CircuitBreaker
+		circuitTarget      string
+		rateLimiter        *RateLimiter
+		rateLimitKey       string
+		timing             *RequestTiming
+		metrics            *ClientMetrics
 	}
 )
 
@@ -54,7 +77,7 @@ func (*HttpClient) NewDelete(url string) *HttpClient { return NewDelete(url) }
 func NewHttpClient(url string) *HttpClient {
 	return &HttpClient{
 		requestUrl:         url,
-		requestQueries:     map[string]string{},
+		requestQueries:     map[string][]string{},
 		requestHeaders:     map[string][]string{"Accept": {}, "Content-Type": {}},
 		responseBody:       []byte{},
 		responseBodyBuffer: bytes.NewBuffer([]byte{}),
@@ -89,6 +112,31 @@ func NewDelete(url string) *HttpClient {
 	return NewHttpClient(url).SetMethod(http.MethodDelete)
 }
 
+// Clone 复制http客户端配置（不包含请求、响应及错误）
+func (my *HttpClient) Clone() *HttpClient {
+	clone := *my
+	clone.Err = nil
+	clone.request = nil
+	clone.response = nil
+	clone.responseBody = []byte{}
+	clone.responseBodyBuffer = bytes.NewBuffer([]byte{})
+	clone.isReady = false
+	clone.retryHistory = nil
+	clone.timing = nil
+	clone.requestQueries = make(url.Values, len(my.requestQueries))
+	for k, v := range my.requestQueries {
+		clone.requestQueries[k] = slices.Clone(v)
+	}
+	clone.requestHeaders = make(map[string][]string, len(my.requestHeaders))
+	for k, v := range my.requestHeaders {
+		clone.requestHeaders[k] = slices.Clone(v)
+	}
+	clone.interceptors = slices.Clone(my.interceptors)
+	clone.clientCerts = slices.Clone(my.clientCerts)
+
+	return &clone
+}
+
 // SetCert 设置SSL证书
 func (my *HttpClient) SetCert(filename string) *HttpClient {
 	var e error
@@ -131,9 +179,12 @@ func (my *HttpClient) AddHeaders(headers map[string][]string) *HttpClient {
 	return my
 }
 
-// SetQueries 设置请求参数
+// SetQueries 设置请求参数：替换已设置的请求参数
 func (my *HttpClient) SetQueries(queries map[string]string) *HttpClient {
-	my.requestQueries = queries
+	my.requestQueries = make(url.Values, len(queries))
+	for k, v := range queries {
+		my.requestQueries.Set(k, v)
+	}
 
 	return my
 }
@@ -186,14 +237,13 @@ func (my *HttpClient) SetFormBody(body map[string]string) *HttpClient {
 	return my
 }
 
-// SetFormDataBody 设置表单数据请求体
+// SetFormDataBody 设置表单数据请求体：files为文件名 => 文件路径，请求体整体读入内存，大文件请使用Multipart流式上传
 func (my *HttpClient) SetFormDataBody(texts map[string]string, files map[string]string) *HttpClient {
 	var (
 		e      error
 		buffer bytes.Buffer
 	)
 
-	my.SetHeaderContentType("form-data")
 	writer := multipart.NewWriter(&buffer)
 	if len(texts) > 0 {
 		for k, v := range texts {
@@ -207,27 +257,42 @@ func (my *HttpClient) SetFormDataBody(texts map[string]string, files map[string]
 
 	if len(files) > 0 {
 		for k, v := range files {
-			fileWriter, _ := writer.CreateFormFile("fileField", k)
-			file, e := os.Open(v)
-			if e != nil {
+			if e = writeFormFile(writer, "fileField", k, v); e != nil {
 				my.Err = SetFormBodyErr.Wrap(e)
 				return my
 			}
-			_, e = io.Copy(fileWriter, file)
-			if e != nil {
-				my.Err = SetFormBodyErr.Wrap(e)
-				return my
-			}
-
-			_ = file.Close()
 		}
 	}
 
-	my.requestBody = []byte(writer.FormDataContentType())
+	if e = writer.Close(); e != nil {
+		my.Err = SetFormBodyErr.Wrap(e)
+		return my
+	}
+
+	my.requestHeaders["Content-Type"] = []string{writer.FormDataContentType()}
+	my.requestBody = buffer.Bytes()
 
 	return my
 }
 
+// writeFormFile 写入表单文件
+func writeFormFile(writer *multipart.Writer, fieldName, filename, path string) error {
+	fileWriter, err := writer.CreateFormFile(fieldName, filename)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(fileWriter, file)
+
+	return err
+}
+
 // SetPlainBody 设置纯文本请求体
 func (my *HttpClient) SetPlainBody(text string) *HttpClient {
 	my.SetHeaderContentType(ContentTypePlain)
@@ -359,9 +424,14 @@ func (my *HttpClient) AppendHeaderAccept(keys ...Accept) *HttpClient {
 	return my
 }
 
-// SetTimeoutSecond 设置超时
+// SetTimeoutSecond 设置超时（秒）
 func (my *HttpClient) SetTimeoutSecond(timeoutSecond int64) *HttpClient {
-	my.timeoutSecond = timeoutSecond
+	return my.SetTimeout(time.Duration(timeoutSecond) * time.Second)
+}
+
+// SetTimeout 设置超时：流式响应仅作用于等待响应头的阶段
+func (my *HttpClient) SetTimeout(timeout time.Duration) *HttpClient {
+	my.timeout = timeout
 
 	return my
 }
@@ -371,10 +441,12 @@ func (my *HttpClient) GetResponse() *http.Response { return my.response }
 
 // ParseByContentType 根据响应头Content-Type自动解析响应体
 func (my *HttpClient) ParseByContentType(target any) *HttpClient {
-	switch ContentType(my.GetResponse().Header.Get("Content-Type")) {
-	case ContentTypeJson:
+	contentType := my.GetResponse().Header.Get("Content-Type")
+
+	switch {
+	case isJsonMediaType(contentType):
 		my.GetResponseJsonBody(target)
-	case ContentTypeXml:
+	case isXmlMediaType(contentType):
 		my.GetResponseXmlBody(target)
 	}
 
@@ -439,16 +511,39 @@ func (my *HttpClient) GetRequest() *http.Request { return my.request }
 
 // GenerateRequest 生成请求对象
 func (my *HttpClient) GenerateRequest() *HttpClient {
-	var e error
+	var (
+		e    error
+		body io.Reader = bytes.NewReader(my.requestBody)
+	)
+
+	// 流式请求体优先
+	if my.requestBodyStream != nil {
+		if body, e = my.requestBodyStream.open(); e != nil {
+			my.Err = GenerateRequestErr.Wrap(e)
+			return my
+		}
+	} else if my.requestEncoding != "" && len(my.requestBody) > 0 {
+		var compressed []byte
+		if compressed, e = my.requestEncoding.compress(my.requestBody); e != nil {
+			my.Err = CompressErr.Wrap(e)
+			return my
+		}
+		body = bytes.NewReader(compressed)
+	}
 
-	my.request, e = http.NewRequest(my.requestMethod, my.requestUrl, bytes.NewReader(my.requestBody))
+	my.request, e = http.NewRequestWithContext(my.getContext(), my.requestMethod, my.requestUrl, body)
 	if e != nil {
 		my.Err = GenerateRequestErr.Wrap(e)
 		return my
 	}
 
+	if my.requestBodyStream != nil {
+		my.requestBodyStream.apply(my.request)
+	}
+
 	// 设置请求头
 	my.addHeaders()
+	my.addEncodingHeaders()
 
 	// 设置url参数
 	my.setQueries()
@@ -458,21 +553,6 @@ func (my *HttpClient) GenerateRequest() *HttpClient {
 		return my
 	}
 
-	// 创建一个新的证书池，并将证书添加到池中
-	if len(my.cert) > 0 {
-		certPool := x509.NewCertPool()
-		if !certPool.AppendCertsFromPEM(my.cert) {
-			my.Err = GenerateCertErr.Panic()
-			return my
-		}
-
-		// 创建一个新的TLS配置
-		tlsConfig := &tls.Config{RootCAs: certPool}
-
-		// 创建一个新的Transport
-		my.transport = &http.Transport{TLSClientConfig: tlsConfig}
-	}
-
 	my.isReady = true
 
 	return my
@@ -489,21 +569,33 @@ func (my *HttpClient) beforeSend() *http.Client {
 
 	my.responseBodyBuffer.Reset() // 重置响应体缓存
 
-	client := &http.Client{}
-
-	// 发送新的请求
-	if my.transport != nil {
-		client.Transport = my.transport
+	// 复用传输层
+	transport, err := my.getTransport()
+	if err != nil {
+		my.Err = err
+		return nil
 	}
 
+	client := &http.Client{Transport: transport, Jar: my.cookieJar}
+
 	// 设置超时
-	if my.timeoutSecond > 0 {
-		client.Timeout = time.Duration(my.timeoutSecond) * time.Second
+	if my.timeout > 0 {
+		client.Timeout = my.timeout
 	}
 
 	return client
 }
 
+// do 执行请求：按重试策略经过拦截器链发送
+func (my *HttpClient) do(client *http.Client) (*http.Response, error) {
+	res, err := my.doWithRetry(client.Do)
+	if err != nil {
+		return res, my.wrapContextErr(err)
+	}
+
+	return my.decodeResponse(res)
+}
+
 // Download 使用下载器下载文件
 func (my *HttpClient) Download(filename string) *HttpClientDownload {
 	return HttpClientDownloadApp.New(my, filename)
@@ -543,7 +635,7 @@ func (my *HttpClient) Send() *HttpClient {
 		return my
 	}
 
-	my.response, my.Err = client.Do(my.request)
+	my.response, my.Err = my.do(client)
 	if my.Err != nil {
 		return my
 	}
@@ -552,14 +644,14 @@ func (my *HttpClient) Send() *HttpClient {
 	// 读取新的响应的主体
 	if my.response.ContentLength > 1*1024*1024 { // 1MB
 		if _, my.Err = io.Copy(my.responseBodyBuffer, my.response.Body); my.Err != nil {
-			my.Err = ReadResponseErr.Wrap(my.Err)
+			my.Err = my.wrapReadErr(my.Err)
 			return my
 		}
 		my.responseBody = my.responseBodyBuffer.Bytes()
 	} else {
 		my.responseBody, my.Err = io.ReadAll(my.response.Body)
 		if my.Err != nil {
-			my.Err = ReadResponseErr.Wrap(my.Err)
+			my.Err = my.wrapReadErr(my.Err)
 			return my
 		}
 	}
@@ -580,16 +672,18 @@ func (my *HttpClient) check() error {
 	return nil
 }
 
-// 设置url参数
+// 设置url参数：与url中已有的参数合并，同名参数以设置的为准
 func (my *HttpClient) setQueries() {
-	if len(my.requestQueries) > 0 {
-		queries := url.Values{}
-		for k, v := range my.requestQueries {
-			queries.Add(k, v)
-		}
+	if len(my.requestQueries) == 0 {
+		return
+	}
 
-		my.requestUrl = str.BufferApp.NewByString(my.requestUrl).String("?", queries.Encode()).ToString()
+	queries := my.request.URL.Query()
+	for k, v := range my.requestQueries {
+		queries[k] = slices.Clone(v)
 	}
+
+	my.request.URL.RawQuery = queries.Encode()
 }
 
 // 设置请求头
@@ -597,4 +691,22 @@ func (my *HttpClient) addHeaders() {
 	for k, v := range my.requestHeaders {
 		my.request.Header[k] = append(my.request.Header[k], v...)
 	}
+
+	// 默认请求头仅在请求自身未设置同名请求头时生效
+	for k, v := range my.defaultHeaders {
+		if !my.hasRequestHeader(k) {
+			my.request.Header[k] = slices.Clone(v)
+		}
+	}
+}
+
+// hasRequestHeader 请求自身是否设置了请求头（不区分大小写，值为空视为未设置）
+func (my *HttpClient) hasRequestHeader(key string) bool {
+	for k, v := range my.requestHeaders {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return true
+		}
+	}
+
+	return false
 }
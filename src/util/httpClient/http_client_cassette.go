@@ -0,0 +1,323 @@
+package httpClient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jericho-yu/nova/src/util/digest"
+	"github.com/jericho-yu/nova/src/util/honestMan"
+)
+
+type (
+	// CassetteMatcher 回放匹配规则
+	CassetteMatcher string
+
+	// CassetteRequest 录制的请求
+	CassetteRequest struct {
+		Method       string              `json:"method" yaml:"method"`
+		Url          string              `json:"url" yaml:"url"`
+		Headers      map[string][]string `json:"headers" yaml:"headers"`
+		Body         string              `json:"body" yaml:"body"`
+		BodyEncoding string              `json:"bodyEncoding,omitempty" yaml:"bodyEncoding,omitempty"`
+		BodyHash     string              `json:"bodyHash" yaml:"bodyHash"`
+	}
+
+	// CassetteResponse 录制的响应
+	CassetteResponse struct {
+		StatusCode   int                 `json:"statusCode" yaml:"statusCode"`
+		Headers      map[string][]string `json:"headers" yaml:"headers"`
+		Body         string              `json:"body" yaml:"body"`
+		BodyEncoding string              `json:"bodyEncoding,omitempty" yaml:"bodyEncoding,omitempty"`
+	}
+
+	// CassetteInteraction 一次请求与响应
+	CassetteInteraction struct {
+		Request  CassetteRequest  `json:"request" yaml:"request"`
+		Response CassetteResponse `json:"response" yaml:"response"`
+	}
+
+	// Cassette 录制与回放：录制时作为拦截器记录真实请求，回放时作为传输层离线返回响应
+	Cassette struct {
+		filename      string
+		interactions  []CassetteInteraction
+		matchers      []CassetteMatcher
+		ignoreHeaders []string
+		replayed      map[int]bool
+		mu            sync.Mutex
+	}
+
+	// cassetteTransport 回放传输层
+	cassetteTransport struct{ cassette *Cassette }
+)
+
+var (
+	CassetteApp Cassette
+
+	CassetteMatchMethod CassetteMatcher = "method"
+	CassetteMatchUrl    CassetteMatcher = "url"
+	CassetteMatchBody   CassetteMatcher = "body"
+)
+
+// New 实例化：录制与回放，文件扩展名为.yaml或.yml时使用yaml格式，否则使用json格式
+func (*Cassette) New(filename string) *Cassette {
+	return &Cassette{
+		filename: filename,
+		matchers: []CassetteMatcher{CassetteMatchMethod, CassetteMatchUrl},
+		replayed: map[int]bool{},
+	}
+}
+
+// SetMatchers 设置回放匹配规则
+func (my *Cassette) SetMatchers(matchers ...CassetteMatcher) *Cassette {
+	my.matchers = matchers
+
+	return my
+}
+
+// SetIgnoreHeaders 设置录制时忽略的请求头，如：Authorization
+func (my *Cassette) SetIgnoreHeaders(headers ...string) *Cassette {
+	my.ignoreHeaders = headers
+
+	return my
+}
+
+// GetInteractions 获取全部录制记录
+func (my *Cassette) GetInteractions() []CassetteInteraction {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	return append([]CassetteInteraction{}, my.interactions...)
+}
+
+// isYaml 是否使用yaml格式
+func (my *Cassette) isYaml() bool {
+	ext := strings.ToLower(filepath.Ext(my.filename))
+
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Load 读取录制文件
+func (my *Cassette) Load() error {
+	var (
+		interactions []CassetteInteraction
+		file         = honestMan.HonestManApp.New(my.filename)
+		err          error
+	)
+
+	if my.isYaml() {
+		err = file.LoadYaml(&interactions)
+	} else {
+		err = file.LoadJson(&interactions)
+	}
+	if err != nil {
+		return CassetteErr.Wrap(err)
+	}
+
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.interactions, my.replayed = interactions, map[int]bool{}
+
+	return nil
+}
+
+// Save 写入录制文件
+func (my *Cassette) Save() error {
+	var (
+		interactions = my.GetInteractions()
+		file         = honestMan.HonestManApp.New(my.filename)
+		err          error
+	)
+
+	if my.isYaml() {
+		err = file.SaveYaml(interactions)
+	} else {
+		err = file.SaveJson(interactions)
+	}
+	if err != nil {
+		return CassetteErr.Wrap(err)
+	}
+
+	return nil
+}
+
+// Record 录制拦截器：记录经过的请求与响应
+func (my *Cassette) Record() Interceptor {
+	return func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+		if isCurlExport(req) {
+			return next(req)
+		}
+
+		requestBody, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := next(req)
+		if err != nil {
+			return nil, err
+		}
+
+		responseBody, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, hc.wrapReadErr(err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+		interaction := CassetteInteraction{
+			Request: CassetteRequest{
+				Method:   req.Method,
+				Url:      req.URL.String(),
+				Headers:  my.filterHeaders(req.Header),
+				BodyHash: hashBody(requestBody),
+			},
+			Response: CassetteResponse{StatusCode: res.StatusCode, Headers: res.Header.Clone()},
+		}
+		interaction.Request.Body, interaction.Request.BodyEncoding = encodeCassetteBody(requestBody)
+		interaction.Response.Body, interaction.Response.BodyEncoding = encodeCassetteBody(responseBody)
+
+		my.mu.Lock()
+		my.interactions = append(my.interactions, interaction)
+		my.mu.Unlock()
+
+		return res, nil
+	}
+}
+
+// Transport 回放传输层：按匹配规则返回录制的响应，相同请求按录制顺序依次返回
+func (my *Cassette) Transport() http.RoundTripper { return &cassetteTransport{cassette: my} }
+
+// RoundTrip 回放请求
+func (my *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction, ok := my.cassette.match(req, hashBody(requestBody))
+	if !ok {
+		return nil, CassetteNotMatchErr.New(req.Method + " " + req.URL.String())
+	}
+
+	body, err := decodeCassetteBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, CassetteErr.Wrap(err)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(interaction.Response.StatusCode),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(interaction.Response.Headers).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// match 查找匹配的录制记录：优先返回未回放过的记录
+func (my *Cassette) match(req *http.Request, bodyHash string) (CassetteInteraction, bool) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	last := -1
+	for idx, interaction := range my.interactions {
+		if !my.matchRequest(interaction.Request, req, bodyHash) {
+			continue
+		}
+
+		if !my.replayed[idx] {
+			my.replayed[idx] = true
+			return interaction, true
+		}
+		last = idx
+	}
+
+	if last >= 0 {
+		return my.interactions[last], true
+	}
+
+	return CassetteInteraction{}, false
+}
+
+// matchRequest 按匹配规则比较请求
+func (my *Cassette) matchRequest(recorded CassetteRequest, req *http.Request, bodyHash string) bool {
+	for _, matcher := range my.matchers {
+		switch matcher {
+		case CassetteMatchMethod:
+			if recorded.Method != req.Method {
+				return false
+			}
+		case CassetteMatchUrl:
+			if recorded.Url != req.URL.String() {
+				return false
+			}
+		case CassetteMatchBody:
+			if recorded.BodyHash != bodyHash {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// filterHeaders 过滤忽略的请求头
+func (my *Cassette) filterHeaders(header http.Header) map[string][]string {
+	headers := header.Clone()
+	for _, key := range my.ignoreHeaders {
+		headers.Del(key)
+	}
+
+	return headers
+}
+
+// readRequestBody 读取请求体并重置，保证后续仍可发送
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, GenerateRequestErr.Wrap(err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// hashBody 计算请求体摘要
+func hashBody(body []byte) string {
+	hash, _ := digest.Sha256(body)
+
+	return hash
+}
+
+// encodeCassetteBody 编码消息体：非utf8内容使用base64
+func encodeCassetteBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decodeCassetteBody 解码消息体
+func decodeCassetteBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return []byte(body), nil
+}
@@ -0,0 +1,50 @@
+package httpClient
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func Test9Cassette(t *testing.T) {
+	for _, filename := range []string{"cassette.json", "cassette.yaml"} {
+		t.Run("录制与回放："+filename, func(t *testing.T) {
+			filename = filepath.Join(t.TempDir(), filename)
+			server := newEchoServer()
+
+			recorder := CassetteApp.New(filename).SetIgnoreHeaders("X-Token")
+			for _, path := range []string{"/a", "/b?page=1"} {
+				hc := NewPost(server.URL + path).SetPlainBody(path).AppendInterceptors(recorder.Record()).AddHeaders(map[string][]string{"X-Token": {"secret"}}).Send()
+				if hc.Err != nil {
+					t.Fatalf("录制失败：%v", hc.Err)
+				}
+			}
+			if err := recorder.Save(); err != nil {
+				t.Fatalf("保存失败：%v", err)
+			}
+			if recorder.GetInteractions()[0].Request.Headers["X-Token"] != nil {
+				t.Errorf("忽略的请求头不应被录制")
+			}
+			url := server.URL
+			server.Close() // 回放时不访问网络
+
+			replayer := CassetteApp.New(filename).SetMatchers(CassetteMatchMethod, CassetteMatchUrl, CassetteMatchBody)
+			if err := replayer.Load(); err != nil {
+				t.Fatalf("读取失败：%v", err)
+			}
+
+			hc := NewPost(url + "/b?page=1").SetPlainBody("/b?page=1").SetTransport(replayer.Transport()).Send()
+			if hc.Err != nil {
+				t.Fatalf("回放失败：%v", hc.Err)
+			}
+			if string(hc.GetResponseRawBody()) != "POST /b?page=1" {
+				t.Errorf("回放响应错误：%s", hc.GetResponseRawBody())
+			}
+
+			hc = NewPost(url + "/b?page=1").SetPlainBody("other").SetTransport(replayer.Transport()).Send()
+			if !errors.Is(hc.Err, &CassetteNotMatchErr) {
+				t.Errorf("期望未匹配错误，实际：%v", hc.Err)
+			}
+		})
+	}
+}
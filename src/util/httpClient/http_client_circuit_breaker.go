@@ -0,0 +1,338 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// CircuitState 熔断器状态
+	CircuitState int
+
+	// circuitOutcome 请求结果
+	circuitOutcome int
+
+	// CircuitBreaker 熔断器：按目标（默认为请求的host）分别统计，失败率超过阈值时打开，冷却后半开试探
+	CircuitBreaker struct {
+		failureRate      float64
+		minRequests      int
+		window           time.Duration
+		cooldown         time.Duration
+		halfOpenRequests int
+		isFailure        func(res *http.Response, err error) bool
+		isIgnored        func(res *http.Response, err error) bool
+		onStateChange    func(target string, from, to CircuitState)
+		circuits         map[string]*circuit
+		mu               sync.Mutex
+	}
+
+	// circuit 单个目标的熔断状态
+	circuit struct {
+		state            CircuitState
+		generation       uint64
+		windowStart      time.Time
+		openedAt         time.Time
+		requests         int
+		failures         int
+		halfOpenInFlight int
+		halfOpenSuccess  int
+	}
+
+	// circuitTransition 状态变更记录
+	circuitTransition struct {
+		target   string
+		from, to CircuitState
+	}
+)
+
+const (
+	CircuitStateClosed CircuitState = iota
+	CircuitStateOpen
+	CircuitStateHalfOpen
+)
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored // 既不算成功也不算失败：仅释放半开试探名额，不计入统计
+)
+
+var CircuitBreakerApp CircuitBreaker
+
+// String 状态名称
+func (my CircuitState) String() string {
+	switch my {
+	case CircuitStateClosed:
+		return "closed"
+	case CircuitStateOpen:
+		return "open"
+	case CircuitStateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// New 实例化：熔断器，默认60秒内至少10次请求且失败率达到50%时打开，冷却30秒后半开放行1个试探请求
+func (*CircuitBreaker) New() *CircuitBreaker {
+	return &CircuitBreaker{
+		failureRate:      0.5,
+		minRequests:      10,
+		window:           time.Minute,
+		cooldown:         30 * time.Second,
+		halfOpenRequests: 1,
+		isFailure:        defaultCircuitFailure,
+		isIgnored:        defaultCircuitIgnored,
+		circuits:         map[string]*circuit{},
+	}
+}
+
+// SetFailureRate 设置打开熔断器的失败率阈值（0-1）
+func (my *CircuitBreaker) SetFailureRate(failureRate float64) *CircuitBreaker {
+	my.failureRate = failureRate
+
+	return my
+}
+
+// SetMinRequests 设置统计窗口内计算失败率的最少请求数
+func (my *CircuitBreaker) SetMinRequests(minRequests int) *CircuitBreaker {
+	my.minRequests = max(minRequests, 1)
+
+	return my
+}
+
+// SetWindow 设置统计窗口
+func (my *CircuitBreaker) SetWindow(window time.Duration) *CircuitBreaker {
+	my.window = window
+
+	return my
+}
+
+// SetCooldown 设置打开后的冷却时间：冷却结束后进入半开状态
+func (my *CircuitBreaker) SetCooldown(cooldown time.Duration) *CircuitBreaker {
+	my.cooldown = cooldown
+
+	return my
+}
+
+// SetHalfOpenRequests 设置半开状态允许的试探请求数：全部成功后关闭
+func (my *CircuitBreaker) SetHalfOpenRequests(halfOpenRequests int) *CircuitBreaker {
+	my.halfOpenRequests = max(halfOpenRequests, 1)
+
+	return my
+}
+
+// SetIsFailure 设置失败判定：默认请求错误或状态码>=500视为失败
+func (my *CircuitBreaker) SetIsFailure(isFailure func(res *http.Response, err error) bool) *CircuitBreaker {
+	my.isFailure = isFailure
+
+	return my
+}
+
+// SetIsIgnored 设置忽略判定：被忽略的请求不计入统计，半开状态下也不会关闭或打开熔断器，默认忽略主动取消与客户端限流
+func (my *CircuitBreaker) SetIsIgnored(isIgnored func(res *http.Response, err error) bool) *CircuitBreaker {
+	my.isIgnored = isIgnored
+
+	return my
+}
+
+// SetOnStateChange 设置状态变更回调
+func (my *CircuitBreaker) SetOnStateChange(onStateChange func(target string, from, to CircuitState)) *CircuitBreaker {
+	my.onStateChange = onStateChange
+
+	return my
+}
+
+// State 获取目标当前状态
+func (my *CircuitBreaker) State(target string) CircuitState {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	c, ok := my.circuits[target]
+	if !ok {
+		return CircuitStateClosed
+	}
+
+	if c.state == CircuitStateOpen && time.Since(c.openedAt) >= my.cooldown {
+		return CircuitStateHalfOpen
+	}
+
+	return c.state
+}
+
+// Reset 重置目标为关闭状态
+func (my *CircuitBreaker) Reset(target string) *CircuitBreaker {
+	my.mu.Lock()
+	c, ok := my.circuits[target]
+	delete(my.circuits, target)
+	my.mu.Unlock()
+
+	if ok && c.state != CircuitStateClosed {
+		my.notify(circuitTransition{target: target, from: c.state, to: CircuitStateClosed})
+	}
+
+	return my
+}
+
+// allow 判断是否放行请求：放行时返回的done需在请求结束后调用
+func (my *CircuitBreaker) allow(target string) (func(res *http.Response, err error), error) {
+	var transitions []circuitTransition
+
+	my.mu.Lock()
+	c, ok := my.circuits[target]
+	if !ok {
+		c = &circuit{windowStart: time.Now()}
+		my.circuits[target] = c
+	}
+
+	if c.state == CircuitStateOpen {
+		if time.Since(c.openedAt) < my.cooldown {
+			my.mu.Unlock()
+			return nil, CircuitOpenErr.New(target)
+		}
+		transitions = append(transitions, my.transit(target, c, CircuitStateHalfOpen))
+	}
+
+	if c.state == CircuitStateHalfOpen {
+		if c.halfOpenInFlight+c.halfOpenSuccess >= my.halfOpenRequests {
+			my.mu.Unlock()
+			my.notify(transitions...)
+			return nil, CircuitOpenErr.New(target + "（半开试探中）")
+		}
+		c.halfOpenInFlight++
+	}
+
+	generation := c.generation
+	my.mu.Unlock()
+	my.notify(transitions...)
+
+	return func(res *http.Response, err error) { my.done(target, generation, my.outcome(res, err)) }, nil
+}
+
+// outcome 判定请求结果
+func (my *CircuitBreaker) outcome(res *http.Response, err error) circuitOutcome {
+	switch {
+	case my.isIgnored != nil && my.isIgnored(res, err):
+		return circuitIgnored
+	case my.isFailure(res, err):
+		return circuitFailure
+	default:
+		return circuitSuccess
+	}
+}
+
+// done 记录请求结果
+func (my *CircuitBreaker) done(target string, generation uint64, outcome circuitOutcome) {
+	var transitions []circuitTransition
+
+	my.mu.Lock()
+	c, ok := my.circuits[target]
+	if !ok || c.generation != generation {
+		my.mu.Unlock()
+		return // 状态已变更，忽略旧状态下发出的请求
+	}
+
+	switch c.state {
+	case CircuitStateClosed:
+		if outcome == circuitIgnored {
+			break
+		}
+		if my.window > 0 && time.Since(c.windowStart) > my.window {
+			c.windowStart, c.requests, c.failures = time.Now(), 0, 0
+		}
+		c.requests++
+		if outcome == circuitFailure {
+			c.failures++
+		}
+		if c.requests >= my.minRequests && float64(c.failures)/float64(c.requests) >= my.failureRate {
+			transitions = append(transitions, my.transit(target, c, CircuitStateOpen))
+		}
+	case CircuitStateHalfOpen:
+		c.halfOpenInFlight--
+		switch outcome {
+		case circuitFailure:
+			transitions = append(transitions, my.transit(target, c, CircuitStateOpen))
+		case circuitSuccess:
+			if c.halfOpenSuccess++; c.halfOpenSuccess >= my.halfOpenRequests {
+				transitions = append(transitions, my.transit(target, c, CircuitStateClosed))
+			}
+		}
+	}
+	my.mu.Unlock()
+
+	my.notify(transitions...)
+}
+
+// transit 变更状态并重置统计：调用方需持有锁
+func (my *CircuitBreaker) transit(target string, c *circuit, to CircuitState) circuitTransition {
+	transition := circuitTransition{target: target, from: c.state, to: to}
+
+	c.state, c.generation = to, c.generation+1
+	c.windowStart, c.requests, c.failures = time.Now(), 0, 0
+	c.halfOpenInFlight, c.halfOpenSuccess = 0, 0
+	if to == CircuitStateOpen {
+		c.openedAt = time.Now()
+	}
+
+	return transition
+}
+
+// notify 在锁外触发状态变更回调
+func (my *CircuitBreaker) notify(transitions ...circuitTransition) {
+	if my.onStateChange == nil {
+		return
+	}
+
+	for _, transition := range transitions {
+		my.onStateChange(transition.target, transition.from, transition.to)
+	}
+}
+
+// defaultCircuitFailure 默认失败判定
+func defaultCircuitFailure(res *http.Response, err error) bool {
+	return err != nil || res != nil && res.StatusCode >= http.StatusInternalServerError
+}
+
+// defaultCircuitIgnored 默认忽略判定：主动取消与客户端限流不代表目标的健康状况
+func defaultCircuitIgnored(_ *http.Response, err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, &RateLimitErr)
+}
+
+// SetCircuitBreaker 设置熔断器：同一熔断器可被多个http客户端共享
+func (my *HttpClient) SetCircuitBreaker(circuitBreaker *CircuitBreaker) *HttpClient {
+	my.circuitBreaker = circuitBreaker
+
+	return my
+}
+
+// SetCircuitTarget 设置熔断统计目标名称：默认使用请求的host
+func (my *HttpClient) SetCircuitTarget(target string) *HttpClient {
+	my.circuitTarget = target
+
+	return my
+}
+
+// breakCircuit 通过熔断器执行请求
+func (my *HttpClient) breakCircuit(req *http.Request, next InterceptorNext) (*http.Response, error) {
+	if my.circuitBreaker == nil {
+		return next(req)
+	}
+
+	target := my.circuitTarget
+	if target == "" {
+		target = req.URL.Host
+	}
+
+	done, err := my.circuitBreaker.allow(target)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := next(req)
+	done(res, err)
+
+	return res, err
+}
@@ -0,0 +1,104 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test13CircuitBreaker(t *testing.T) {
+	var (
+		healthy atomic.Bool
+		hits    atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		transitions []string
+		mu          sync.Mutex
+		breaker     = CircuitBreakerApp.New().SetMinRequests(3).SetFailureRate(0.5).SetCooldown(100 * time.Millisecond).
+				SetOnStateChange(func(target string, from, to CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+				transitions = append(transitions, from.String()+"->"+to.String())
+			})
+	)
+
+	t.Run("熔断：失败率达到阈值后打开", func(t *testing.T) {
+		for range 3 {
+			NewGet(server.URL).SetCircuitBreaker(breaker).Send()
+		}
+
+		hits.Store(0)
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).Send(); !errors.Is(hc.Err, &CircuitOpenErr) || hits.Load() != 0 {
+			t.Fatalf("期望熔断，实际：%v", hc.Err)
+		}
+		if state := breaker.State(server.Listener.Addr().String()); state != CircuitStateOpen {
+			t.Errorf("状态错误：%s", state)
+		}
+	})
+
+	t.Run("熔断：独立目标不受影响", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).SetCircuitTarget("other").Send(); hc.Err != nil {
+			t.Errorf("其他目标不应熔断：%v", hc.Err)
+		}
+	})
+
+	t.Run("熔断：冷却后半开试探并关闭", func(t *testing.T) {
+		time.Sleep(150 * time.Millisecond)
+		healthy.Store(true)
+
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).Send(); hc.Err != nil {
+			t.Fatalf("半开试探失败：%v", hc.Err)
+		}
+		if state := breaker.State(server.Listener.Addr().String()); state != CircuitStateClosed {
+			t.Errorf("状态错误：%s", state)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(transitions) != 3 || transitions[0] != "closed->open" || transitions[1] != "open->half-open" || transitions[2] != "half-open->closed" {
+			t.Errorf("状态变更错误：%v", transitions)
+		}
+	})
+
+	t.Run("熔断：半开状态下取消请求不改变状态", func(t *testing.T) {
+		var (
+			target  = server.Listener.Addr().String()
+			breaker = CircuitBreakerApp.New().SetMinRequests(1).SetCooldown(50 * time.Millisecond)
+		)
+
+		healthy.Store(false)
+		NewGet(server.URL).SetCircuitBreaker(breaker).Send()
+		time.Sleep(80 * time.Millisecond)
+		healthy.Store(true)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).SendWithContext(ctx); !errors.Is(hc.Err, &RequestCanceledErr) {
+			t.Fatalf("期望取消错误，实际：%v", hc.Err)
+		}
+		if state := breaker.State(target); state != CircuitStateHalfOpen {
+			t.Errorf("取消的请求不应改变状态：%s", state)
+		}
+
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).Send(); hc.Err != nil {
+			t.Fatalf("半开试探失败：%v", hc.Err)
+		}
+		if state := breaker.State(target); state != CircuitStateClosed {
+			t.Errorf("状态错误：%s", state)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package httpClient
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jericho-yu/nova/src/util/compression"
+)
+
+type (
+	// ContentEncoding 内容编码
+	ContentEncoding string
+
+	// decodedBody 解压后的响应体：关闭时同时关闭原响应体
+	decodedBody struct {
+		io.Reader
+		decoder io.Closer
+		body    io.Closer
+	}
+)
+
+var (
+	ContentEncodingGzip    ContentEncoding = "gzip"
+	ContentEncodingDeflate ContentEncoding = "deflate" // 按RFC 9110使用zlib格式，解压时兼容裸deflate
+	ContentEncodingZlib    ContentEncoding = "zlib"    // 非标准编码，部分旧服务使用
+)
+
+// SetRequestEncoding 设置请求体压缩：设置Content-Encoding请求头，流式请求体不压缩
+func (my *HttpClient) SetRequestEncoding(encoding ContentEncoding) *HttpClient {
+	my.requestEncoding = encoding
+
+	return my
+}
+
+// SetAcceptEncodings 设置可接受的响应编码：设置Accept-Encoding请求头，并自动解压对应编码的响应体
+func (my *HttpClient) SetAcceptEncodings(encodings ...ContentEncoding) *HttpClient {
+	my.acceptEncodings = encodings
+
+	return my
+}
+
+// addEncodingHeaders 设置编码相关请求头
+func (my *HttpClient) addEncodingHeaders() {
+	if my.requestEncoding != "" && my.requestBodyStream == nil && len(my.requestBody) > 0 {
+		my.request.Header.Set("Content-Encoding", string(my.requestEncoding))
+	}
+
+	if len(my.acceptEncodings) > 0 {
+		encodings := make([]string, len(my.acceptEncodings))
+		for idx, encoding := range my.acceptEncodings {
+			encodings[idx] = string(encoding)
+		}
+		my.request.Header.Set("Accept-Encoding", strings.Join(encodings, ", "))
+	}
+}
+
+// decodeResponse 解压响应体：未设置可接受的响应编码时由标准库处理gzip
+func (my *HttpClient) decodeResponse(res *http.Response) (*http.Response, error) {
+	if len(my.acceptEncodings) == 0 || res.Body == nil || res.Body == http.NoBody {
+		return res, nil
+	}
+
+	encoding := ContentEncoding(strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding"))))
+	if encoding == "" || encoding == "identity" {
+		return res, nil
+	}
+
+	decoder, err := encoding.decoder(res.Body)
+	if err != nil {
+		_ = res.Body.Close()
+		return nil, DecompressErr.Wrap(err)
+	}
+	if decoder == nil {
+		return res, nil // 不支持的编码，保持原样
+	}
+
+	res.Body = &decodedBody{Reader: decoder, decoder: decoder, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return res, nil
+}
+
+// compress 压缩
+func (my ContentEncoding) compress(data []byte) ([]byte, error) {
+	switch my {
+	case ContentEncodingGzip:
+		return compression.GzipApp.New().Compress(data)
+	case ContentEncodingDeflate, ContentEncodingZlib:
+		return compression.ZlibApp.New().Compress(data)
+	default:
+		return nil, CompressErr.New("不支持的编码：" + string(my))
+	}
+}
+
+// decoder 创建解压器：不支持的编码返回nil
+func (my ContentEncoding) decoder(body io.Reader) (io.ReadCloser, error) {
+	switch my {
+	case ContentEncodingGzip, "x-gzip":
+		return compression.GzipApp.New().NewReader(body)
+	case ContentEncodingZlib:
+		return compression.ZlibApp.New().NewReader(body)
+	case ContentEncodingDeflate:
+		reader := bufio.NewReader(body)
+		if header, err := reader.Peek(2); err == nil && isZlibHeader(header) {
+			return compression.ZlibApp.New().NewReader(reader)
+		}
+		return compression.FlateApp.New().NewReader(reader)
+	default:
+		return nil, nil
+	}
+}
+
+// isZlibHeader 是否zlib头：CM为8且头部校验通过
+func isZlibHeader(header []byte) bool {
+	return header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// Close 关闭解压器与原响应体
+func (my *decodedBody) Close() error {
+	_ = my.decoder.Close()
+
+	return my.body.Close()
+}
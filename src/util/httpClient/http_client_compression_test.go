@@ -0,0 +1,63 @@
+package httpClient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jericho-yu/nova/src/util/compression"
+)
+
+func Test17Compression(t *testing.T) {
+	content := strings.Repeat("nova", 1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upload":
+			reader, err := compression.GzipApp.New().NewReader(r.Body)
+			if r.Header.Get("Content-Encoding") != "gzip" || err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body, _ := io.ReadAll(reader)
+			_, _ = w.Write(body)
+		case "/zlib":
+			w.Header().Set("Content-Encoding", "deflate")
+			writer := zlib.NewWriter(w)
+			_, _ = writer.Write([]byte(r.Header.Get("Accept-Encoding")))
+			_ = writer.Close()
+		case "/deflate":
+			w.Header().Set("Content-Encoding", "deflate")
+			writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+			_, _ = writer.Write([]byte(content))
+			_ = writer.Close()
+		}
+	}))
+	defer server.Close()
+
+	t.Run("压缩请求体", func(t *testing.T) {
+		hc := NewPost(server.URL + "/upload").SetPlainBody(content).SetRequestEncoding(ContentEncodingGzip).Send()
+		if hc.Err != nil || string(hc.GetResponseRawBody()) != content {
+			t.Errorf("压缩请求体失败：%v %d", hc.Err, hc.GetResponse().StatusCode)
+		}
+	})
+
+	t.Run("解压zlib格式的deflate响应", func(t *testing.T) {
+		hc := NewGet(server.URL+"/zlib").SetAcceptEncodings(ContentEncodingGzip, ContentEncodingDeflate).Send()
+		if hc.Err != nil || string(hc.GetResponseRawBody()) != "gzip, deflate" || hc.GetResponse().Header.Get("Content-Encoding") != "" {
+			t.Errorf("解压失败：%v %s", hc.Err, hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("解压裸deflate响应", func(t *testing.T) {
+		hc := NewGet(server.URL + "/deflate").SetAcceptEncodings(ContentEncodingDeflate).Send()
+		if hc.Err != nil || !bytes.Equal(hc.GetResponseRawBody(), []byte(content)) {
+			t.Errorf("解压失败：%v", hc.Err)
+		}
+	})
+}
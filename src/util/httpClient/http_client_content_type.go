@@ -28,23 +28,27 @@ var (
 		ContentTypeSteam:      "application/octet-stream",
 	}
 
-	AcceptJson       Accept = "json"
-	AcceptXml        Accept = "xml"
-	AcceptPlain      Accept = "plain"
-	AcceptHtml       Accept = "html"
-	AcceptCss        Accept = "css"
-	AcceptJavascript Accept = "javascript"
-	AcceptSteam      Accept = "steam"
-	AcceptAny        Accept = "any"
+	AcceptJson        Accept = "json"
+	AcceptXml         Accept = "xml"
+	AcceptPlain       Accept = "plain"
+	AcceptHtml        Accept = "html"
+	AcceptCss         Accept = "css"
+	AcceptJavascript  Accept = "javascript"
+	AcceptSteam       Accept = "steam"
+	AcceptAny         Accept = "any"
+	AcceptEventStream Accept = "event-stream"
+	AcceptNdjson      Accept = "ndjson"
 
 	Accepts = map[Accept]string{
-		AcceptJson:       "application/json",
-		AcceptXml:        "application/xml",
-		AcceptPlain:      "text/plain",
-		AcceptHtml:       "text/html",
-		AcceptCss:        "text/css",
-		AcceptJavascript: "text/javascript",
-		AcceptSteam:      "application/octet-stream",
-		AcceptAny:        "*/*",
+		AcceptJson:        "application/json",
+		AcceptXml:         "application/xml",
+		AcceptPlain:       "text/plain",
+		AcceptHtml:        "text/html",
+		AcceptCss:         "text/css",
+		AcceptJavascript:  "text/javascript",
+		AcceptSteam:       "application/octet-stream",
+		AcceptAny:         "*/*",
+		AcceptEventStream: "text/event-stream",
+		AcceptNdjson:      "application/x-ndjson",
 	}
 )
@@ -0,0 +1,102 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// SetContext 设置上下文
+func (my *HttpClient) SetContext(ctx context.Context) *HttpClient {
+	my.ctx = ctx
+
+	return my
+}
+
+// GetContext 获取上下文
+func (my *HttpClient) GetContext() context.Context { return my.getContext() }
+
+// SendWithContext 使用上下文发送请求：上下文仅作用于本次发送
+func (my *HttpClient) SendWithContext(ctx context.Context) *HttpClient {
+	my.withContext(ctx, func() { my.Send() })
+
+	return my
+}
+
+// withContext 临时使用上下文执行，执行后恢复原上下文
+func (my *HttpClient) withContext(ctx context.Context, fn func()) {
+	previous := my.ctx
+	defer func() { my.ctx = previous }()
+
+	my.ctx = ctx
+	fn()
+}
+
+// getContext 获取上下文：未设置时使用context.Background
+func (my *HttpClient) getContext() context.Context {
+	return orBackground(my.ctx)
+}
+
+// isTimeoutErr 是否超时错误
+func isTimeoutErr(err error) bool {
+	var netErr net.Error
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// wrapContextErr 包装取消与超时错误
+func (my *HttpClient) wrapContextErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled):
+		return RequestCanceledErr.Wrap(err)
+	case isTimeoutErr(err):
+		return RequestTimeoutErr.Wrap(err)
+	default:
+		return err
+	}
+}
+
+// wrapReadErr 包装读取响应体错误
+func (my *HttpClient) wrapReadErr(err error) error {
+	if errors.Is(err, context.Canceled) || isTimeoutErr(err) {
+		return my.wrapContextErr(err)
+	}
+
+	return ReadResponseErr.Wrap(err)
+}
+
+// orBackground 获取上下文：未设置时使用context.Background
+func orBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
+}
+
+// mergeContext 合并上下文：parent或other任一结束时结束，并继承other的截止时间
+func mergeContext(parent, other context.Context) (context.Context, context.CancelFunc) {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	if deadline, ok := other.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(parent, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+
+	stop := context.AfterFunc(other, func() {
+		if !errors.Is(other.Err(), context.DeadlineExceeded) {
+			cancel() // 超时由截止时间处理，保证错误类型一致
+		}
+	})
+
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,94 @@
+package httpClient
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// curlExportKey 导出curl命令时的上下文标记
+type curlExportKey struct{}
+
+// ToCurl 将请求导出为可复现的curl命令：在客户端副本上生成请求并经过拦截器链（不实际发送），包含拦截器、令牌设置的请求头及cookie容器中的cookie；
+// 压缩的请求体以原文导出（不包含Content-Encoding请求头），流式请求体无法导出，以@-代替（从标准输入读取）；拦截器短路请求时返回拦截错误
+func (my *HttpClient) ToCurl() (string, error) {
+	if my.Err != nil {
+		return "", my.Err
+	}
+
+	var (
+		clone = my.Clone()
+		req   *http.Request
+	)
+
+	if clone.GenerateRequest(); clone.Err != nil {
+		return "", clone.Err
+	}
+
+	_, err := clone.intercept(clone.request.WithContext(context.WithValue(clone.request.Context(), curlExportKey{}, true)), func(r *http.Request) (*http.Response, error) {
+		req = r
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if req == nil { // 拦截器未调用next，请求不会发出
+		return "", InterceptErr.New("拦截器短路了请求，无法导出curl命令")
+	}
+
+	if clone.cookieJar != nil {
+		for _, cookie := range clone.cookieJar.Cookies(req.URL) {
+			req.AddCookie(cookie)
+		}
+	}
+
+	parts := []string{"curl"}
+	if req.Method != "" && req.Method != http.MethodGet {
+		parts = append(parts, "-X", req.Method)
+	}
+
+	parts = append(parts, shellQuote(req.URL.String()))
+
+	compressed := clone.requestEncoding != "" && clone.requestBodyStream == nil && len(clone.requestBody) > 0
+	for _, key := range slices.Sorted(maps.Keys(req.Header)) {
+		if compressed && strings.EqualFold(key, "Content-Encoding") {
+			continue
+		}
+		for _, value := range req.Header[key] {
+			parts = append(parts, "-H", shellQuote(key+": "+value))
+		}
+	}
+
+	switch {
+	case clone.requestBodyStream != nil:
+		parts = append(parts, "--data-binary", "@-")
+	case compressed:
+		parts = append(parts, "--data-raw", shellQuote(string(clone.requestBody)))
+	case req.GetBody != nil && req.ContentLength != 0:
+		body, err := req.GetBody()
+		if err != nil {
+			return "", GenerateRequestErr.Wrap(err)
+		}
+		content, err := io.ReadAll(body)
+		_ = body.Close()
+		if err != nil {
+			return "", GenerateRequestErr.Wrap(err)
+		}
+		parts = append(parts, "--data-raw", shellQuote(string(content)))
+	}
+
+	if clone.insecureSkipVerify {
+		parts = append(parts, "-k")
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// isCurlExport 是否为导出curl命令时的模拟请求：记录类拦截器应跳过
+func isCurlExport(req *http.Request) bool { return req.Context().Value(curlExportKey{}) != nil }
+
+// shellQuote 使用单引号转义shell参数
+func shellQuote(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
@@ -1,23 +1,54 @@
 package httpClient
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/jericho-yu/nova/src/util/array"
 	"github.com/jericho-yu/nova/src/util/dict"
+	"github.com/jericho-yu/nova/src/util/digest"
+	"github.com/jericho-yu/nova/src/util/operation"
 
 	processBar "github.com/schollz/progressbar/v3"
 )
 
-type HttpClientDownload struct {
-	httpClient     *HttpClient
-	filename       string
-	processContent string
-}
+type (
+	HttpClientDownload struct {
+		httpClient        *HttpClient
+		filename          string
+		processContent    string
+		resume            bool
+		segments          int
+		checksumAlgorithm DigestAlgorithm
+		checksum          string
+	}
+
+	// DigestAlgorithm 文件校验摘要算法
+	DigestAlgorithm string
+)
+
+var (
+	HttpClientDownloadApp HttpClientDownload
 
-var HttpClientDownloadApp HttpClientDownload
+	DigestAlgorithmMd5    DigestAlgorithm = "md5"
+	DigestAlgorithmSha256 DigestAlgorithm = "sha256"
+	DigestAlgorithmSm3    DigestAlgorithm = "sm3"
+
+	errRangeMismatch = errors.New("服务端返回的范围与请求不一致")
+
+	digestReaders = map[DigestAlgorithm]func(reader io.Reader) (string, error){
+		DigestAlgorithmMd5:    digest.Md5Reader,
+		DigestAlgorithmSha256: digest.Sha256Reader,
+		DigestAlgorithmSm3:    digest.Sm3Reader,
+	}
+)
 
 // New 实例化http客户端下载器
 func (*HttpClientDownload) New(httpClient *HttpClient, filename string) *HttpClientDownload {
@@ -31,34 +62,362 @@ func (my *HttpClientDownload) SetProcessContent(processContent string) *HttpClie
 	return my
 }
 
-// Save 保存到本地
+// SetResume 设置断点续传：失败时保留临时文件（filename.part），下次下载从已有位置继续
+func (my *HttpClientDownload) SetResume(resume bool) *HttpClientDownload {
+	my.resume = resume
+
+	return my
+}
+
+// SetSegments 设置分段并行下载的段数：服务端不支持Range时自动退化为单线程下载
+func (my *HttpClientDownload) SetSegments(segments int) *HttpClientDownload {
+	my.segments = segments
+
+	return my
+}
+
+// SetChecksum 设置下载完成后的文件校验摘要（十六进制）
+func (my *HttpClientDownload) SetChecksum(algorithm DigestAlgorithm, checksum string) *HttpClientDownload {
+	my.checksumAlgorithm = algorithm
+	my.checksum = checksum
+
+	return my
+}
+
+// SaveLocal 保存到本地：先写入临时文件，校验通过后原子重命名为目标文件
 func (my *HttpClientDownload) SaveLocal() *HttpClient {
+	var (
+		err          error
+		tempFilename = my.filename + ".part"
+	)
+
 	defer func() { my.httpClient.isReady = false }()
 
-	client := my.httpClient.beforeSend()
 	if my.httpClient.Err != nil {
 		return my.httpClient
 	}
 
-	if my.httpClient.response, my.httpClient.Err = client.Do(my.httpClient.request); my.httpClient.Err != nil {
-		return my.httpClient
+	if my.segments > 1 {
+		err = my.saveParallel(tempFilename)
 	} else {
-		defer my.httpClient.response.Body.Close()
+		err = my.saveSingle(tempFilename)
+	}
 
-		f, _ := os.OpenFile(my.filename, os.O_RDWR|os.O_CREATE, 0644)
-		defer f.Close()
+	if err == nil {
+		err = my.verify(tempFilename)
+	}
 
-		if my.processContent != "" {
-			_, _ = io.Copy(io.MultiWriter(f, processBar.DefaultBytes(my.httpClient.response.ContentLength, my.processContent)), my.httpClient.response.Body)
-		} else {
-			_, _ = io.Copy(f, my.httpClient.response.Body)
+	if err == nil {
+		if e := os.Rename(tempFilename, my.filename); e != nil {
+			err = DownloadErr.Wrap(e)
 		}
+	}
 
-		return my.httpClient
+	if err != nil {
+		if !my.resume || errors.Is(err, &ChecksumMismatchErr) {
+			_ = os.Remove(tempFilename)
+		}
+		my.httpClient.Err = err
+	}
+
+	return my.httpClient
+}
+
+// SaveLocalWithContext 使用上下文保存到本地：上下文仅作用于本次下载
+func (my *HttpClientDownload) SaveLocalWithContext(ctx context.Context) *HttpClient {
+	my.httpClient.withContext(ctx, func() { my.SaveLocal() })
+
+	return my.httpClient
+}
+
+// saveSingle 单线程下载
+func (my *HttpClientDownload) saveSingle(tempFilename string) (err error) {
+	var offset int64
+
+	if my.resume {
+		if stat, e := os.Stat(tempFilename); e == nil {
+			offset = stat.Size()
+		}
+	}
+
+	res, err := my.fetch(my.httpClient.getContext(), tempFilename, offset, offset, -1, nil)
+	if errors.Is(err, errRangeMismatch) {
+		res, err = my.fetch(my.httpClient.getContext(), tempFilename, 0, 0, -1, nil) // 续传位置不一致，重新完整下载
+	}
+	my.httpClient.response = res
+
+	return err
+}
+
+// saveParallel 分段并行下载：每段写入独立的临时文件，全部完成后合并，响应为第一段的响应（第一段已下载完成时为探测请求的响应）
+func (my *HttpClientDownload) saveParallel(tempFilename string) error {
+	total, res := my.probe()
+	if total <= 0 {
+		return my.saveSingle(tempFilename)
+	}
+	my.httpClient.response = res
+
+	var (
+		size        = (total + int64(my.segments) - 1) / int64(my.segments)
+		ctx, cancel = context.WithCancel(my.httpClient.getContext())
+		parts       []string
+		bar         *processBar.ProgressBar
+		wg          sync.WaitGroup
+		once        sync.Once
+		firstErr    error
+	)
+	defer cancel()
+
+	if my.processContent != "" {
+		bar = processBar.DefaultBytes(total, my.processContent)
+	}
+
+	for start := int64(0); start < total; start += size {
+		var (
+			end    = min(total, start+size) - 1
+			part   = fmt.Sprintf("%s.%d", tempFilename, len(parts))
+			offset int64
+		)
+		parts = append(parts, part)
+
+		if my.resume {
+			if stat, e := os.Stat(part); e == nil && stat.Size() <= end-start+1 {
+				offset = stat.Size()
+			}
+		}
+		if bar != nil {
+			_ = bar.Add64(offset)
+		}
+		if start+offset > end {
+			continue // 分段已下载完成
+		}
+
+		wg.Add(1)
+		go func(part string, offset, start, end int64, first bool) {
+			defer wg.Done()
+
+			res, err := my.fetch(ctx, part, offset, start, end, bar)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+			if first && res != nil {
+				my.httpClient.response = res
+			}
+		}(part, offset, start+offset, end, len(parts) == 1)
+	}
+
+	wg.Wait()
+
+	if errors.Is(firstErr, errRangeMismatch) {
+		// 服务端未按请求范围返回，放弃分段重新完整下载
+		for _, part := range parts {
+			_ = os.Remove(part)
+		}
+		_ = os.Remove(tempFilename)
+
+		return my.saveSingle(tempFilename)
+	}
+
+	if firstErr != nil {
+		if !my.resume {
+			for _, part := range parts {
+				_ = os.Remove(part)
+			}
+		}
+		return firstErr
+	}
+
+	return my.merge(tempFilename, parts)
+}
+
+// probe 探测文件大小：服务端不支持Range时返回-1
+func (my *HttpClientDownload) probe() (int64, *http.Response) {
+	hc := my.httpClient.Clone()
+	hc.requestHeaders["Range"] = []string{"bytes=0-0"}
+
+	client := hc.beforeSend()
+	if hc.Err != nil {
+		return -1, nil
+	}
+
+	res, err := hc.do(client)
+	if err != nil {
+		return -1, nil
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode != http.StatusPartialContent {
+		return -1, res
+	}
+
+	return contentRangeTotal(res), res
+}
+
+// fetch 下载指定范围到文件：fileOffset为文件中已有的有效字节数，rangeEnd小于0表示到文件末尾
+func (my *HttpClientDownload) fetch(ctx context.Context, filename string, fileOffset, rangeStart, rangeEnd int64, bar *processBar.ProgressBar) (*http.Response, error) {
+	hc := my.httpClient.Clone().SetContext(ctx)
+	if rangeStart > 0 || rangeEnd >= 0 {
+		hc.requestHeaders["Range"] = []string{operation.Ternary(rangeEnd >= 0, fmt.Sprintf("bytes=%d-%d", rangeStart, rangeEnd), fmt.Sprintf("bytes=%d-", rangeStart))}
+	}
+
+	client := hc.beforeSend()
+	if hc.Err != nil {
+		return nil, hc.Err
+	}
+
+	res, err := hc.do(client)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusPartialContent:
+		if contentRangeStart(res) != rangeStart {
+			return res, errRangeMismatch
+		}
+	case res.StatusCode == http.StatusOK && rangeEnd < 0:
+		fileOffset = 0 // 服务端不支持断点续传，重新下载
+	case res.StatusCode == http.StatusOK:
+		return res, errRangeMismatch
+	case res.StatusCode == http.StatusRequestedRangeNotSatisfiable && rangeEnd < 0 && contentRangeTotal(res) == fileOffset:
+		return res, nil // 已下载完成
+	default:
+		return res, DownloadErr.New(res.Status)
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return res, DownloadErr.Wrap(err)
+	}
+	defer f.Close()
+
+	if err = f.Truncate(fileOffset); err != nil {
+		return res, DownloadErr.Wrap(err)
+	}
+	if _, err = f.Seek(fileOffset, io.SeekStart); err != nil {
+		return res, DownloadErr.Wrap(err)
+	}
+
+	var writer io.Writer = f
+	if bar == nil && my.processContent != "" {
+		bar = processBar.DefaultBytes(operation.Ternary(res.ContentLength >= 0, fileOffset+res.ContentLength, -1), my.processContent)
+		_ = bar.Set64(fileOffset)
+	}
+	if bar != nil {
+		writer = io.MultiWriter(f, bar)
+	}
+
+	if _, err = io.Copy(writer, res.Body); err != nil {
+		return res, hc.wrapReadErr(err)
+	}
+
+	return res, nil
+}
+
+// merge 合并分段文件
+func (my *HttpClientDownload) merge(tempFilename string, parts []string) error {
+	f, err := os.OpenFile(tempFilename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return DownloadErr.Wrap(err)
+	}
+	defer f.Close()
+
+	for _, part := range parts {
+		if err = my.appendFile(f, part); err != nil {
+			return DownloadErr.Wrap(err)
+		}
+	}
+
+	for _, part := range parts {
+		_ = os.Remove(part)
+	}
+
+	return nil
+}
+
+// appendFile 追加文件内容
+func (my *HttpClientDownload) appendFile(writer io.Writer, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(writer, f)
+
+	return err
+}
+
+// verify 校验文件摘要
+func (my *HttpClientDownload) verify(filename string) error {
+	if my.checksum == "" {
+		return nil
+	}
+
+	digestReader, ok := digestReaders[my.checksumAlgorithm]
+	if !ok {
+		return ChecksumMismatchErr.New(fmt.Sprintf("不支持的摘要算法：%s", my.checksumAlgorithm))
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return DownloadErr.Wrap(err)
+	}
+	defer f.Close()
+
+	checksum, err := digestReader(f)
+	if err != nil {
+		return DownloadErr.Wrap(err)
 	}
+
+	if !strings.EqualFold(checksum, my.checksum) {
+		return ChecksumMismatchErr.New(fmt.Sprintf("期望：%s，实际：%s", my.checksum, checksum))
+	}
+
+	return nil
+}
+
+// contentRangeStart 解析响应头Content-Range中的起始位置：无法解析时返回-1
+func contentRangeStart(res *http.Response) int64 {
+	value, ok := strings.CutPrefix(res.Header.Get("Content-Range"), "bytes ")
+	if !ok {
+		return -1
+	}
+
+	start, _, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return -1
+	}
+
+	size, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+// contentRangeTotal 解析响应头Content-Range中的文件总大小：未知时返回-1
+func contentRangeTotal(res *http.Response) int64 {
+	_, total, ok := strings.Cut(res.Header.Get("Content-Range"), "/")
+	if !ok {
+		return -1
+	}
+
+	size, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return size
 }
 
-// Send 发送到客户端
+// SendResponse 发送到客户端
 func (my *HttpClientDownload) SendResponse(w http.ResponseWriter, headers map[string][]string) *HttpClient {
 	defer func() { my.httpClient.isReady = false }()
 
@@ -67,7 +426,7 @@ func (my *HttpClientDownload) SendResponse(w http.ResponseWriter, headers map[st
 		return nil
 	}
 
-	if my.httpClient.response, my.httpClient.Err = client.Do(my.httpClient.request); my.httpClient.Err != nil {
+	if my.httpClient.response, my.httpClient.Err = my.httpClient.do(client); my.httpClient.Err != nil {
 		return nil
 	} else {
 		defer my.httpClient.response.Body.Close()
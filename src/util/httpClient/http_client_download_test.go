@@ -0,0 +1,110 @@
+package httpClient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jericho-yu/nova/src/util/digest"
+)
+
+func Test8Download(t *testing.T) {
+	var (
+		content     = bytes.Repeat([]byte("0123456789abcdef"), 4096)
+		checksum, _ = digest.Sha256(content)
+		rangeTimes  atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			rangeTimes.Add(1)
+		}
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	t.Run("下载：断点续传", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "file.bin")
+		if err := os.WriteFile(filename+".part", content[:1000], 0644); err != nil {
+			t.Fatalf("写入临时文件失败：%v", err)
+		}
+
+		rangeTimes.Store(0)
+		hc := NewGet(server.URL).Download(filename).SetResume(true).SetChecksum(DigestAlgorithmSha256, checksum).SaveLocal()
+		if hc.Err != nil {
+			t.Fatalf("下载失败：%v", hc.Err)
+		}
+		if hc.GetResponse().StatusCode != http.StatusPartialContent || rangeTimes.Load() != 1 {
+			t.Errorf("未使用断点续传：%d", hc.GetResponse().StatusCode)
+		}
+		if _, err := os.Stat(filename + ".part"); !os.IsNotExist(err) {
+			t.Errorf("临时文件未清理")
+		}
+	})
+
+	t.Run("下载：分段并行", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "file.bin")
+
+		rangeTimes.Store(0)
+		hc := NewGet(server.URL).Download(filename).SetSegments(4).SetChecksum(DigestAlgorithmSha256, checksum).SaveLocal()
+		if hc.Err != nil {
+			t.Fatalf("下载失败：%v", hc.Err)
+		}
+		if rangeTimes.Load() != 5 {
+			t.Errorf("分段请求次数错误：%d", rangeTimes.Load())
+		}
+		if hc.GetResponse() == nil || hc.GetResponse().StatusCode != http.StatusPartialContent {
+			t.Errorf("分段下载后应保留响应")
+		}
+		if data, _ := os.ReadFile(filename); !bytes.Equal(data, content) {
+			t.Errorf("文件内容错误")
+		}
+	})
+
+	t.Run("下载：续传范围不一致时重新下载", func(t *testing.T) {
+		misaligned := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content))) // 忽略请求的起始位置
+				w.WriteHeader(http.StatusPartialContent)
+			}
+			_, _ = w.Write(content)
+		}))
+		defer misaligned.Close()
+
+		for name, segments := range map[string]int{"单线程": 1, "分段并行": 4} {
+			t.Run(name, func(t *testing.T) {
+				filename := filepath.Join(t.TempDir(), "file.bin")
+				if err := os.WriteFile(filename+".part", content[:1000], 0644); err != nil {
+					t.Fatalf("写入临时文件失败：%v", err)
+				}
+
+				hc := NewGet(misaligned.URL).Download(filename).SetResume(true).SetSegments(segments).SetChecksum(DigestAlgorithmSha256, checksum).SaveLocal()
+				if hc.Err != nil {
+					t.Fatalf("下载失败：%v", hc.Err)
+				}
+				if data, _ := os.ReadFile(filename); !bytes.Equal(data, content) {
+					t.Errorf("文件内容错误")
+				}
+			})
+		}
+	})
+
+	t.Run("下载：校验失败", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "file.bin")
+
+		hc := NewGet(server.URL).Download(filename).SetChecksum(DigestAlgorithmMd5, "00000000000000000000000000000000").SaveLocal()
+		if !errors.Is(hc.Err, &ChecksumMismatchErr) {
+			t.Errorf("期望校验失败，实际：%v", hc.Err)
+		}
+		if _, err := os.Stat(filename); !os.IsNotExist(err) {
+			t.Errorf("校验失败时不应生成目标文件")
+		}
+	})
+}
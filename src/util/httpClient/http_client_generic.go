@@ -0,0 +1,93 @@
+package httpClient
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ResponseError 非2xx响应错误：携带状态码、响应头、原始响应体及解析后的错误体
+type ResponseError[E any] struct {
+	StatusCode int
+	Header     http.Header
+	RawBody    []byte
+	Body       *E // 错误体解析失败时为nil
+}
+
+// Error 错误信息
+func (my *ResponseError[E]) Error() string {
+	return ResponseStatusErr.New(fmt.Sprintf("%d %s", my.StatusCode, my.RawBody)).Error()
+}
+
+// Is 可通过errors.Is(err, &ResponseStatusErr)判断
+func (my *ResponseError[E]) Is(target error) bool {
+	return reflect.DeepEqual(target, &ResponseStatusErr)
+}
+
+// Do 发送请求并按状态码解析响应：2xx解析为T，其余解析为E并返回*ResponseError[E]
+func Do[T, E any](hc *HttpClient) (*T, error) {
+	if hc.Send().Err != nil {
+		return nil, hc.Err
+	}
+
+	var (
+		res = hc.GetResponse()
+		raw = hc.GetResponseRawBody()
+	)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		var (
+			body        E
+			responseErr = &ResponseError[E]{StatusCode: res.StatusCode, Header: res.Header, RawBody: raw}
+		)
+
+		if len(raw) > 0 && decodeBody(res.Header.Get("Content-Type"), raw, &body) == nil {
+			responseErr.Body = &body
+		}
+
+		return nil, responseErr
+	}
+
+	var target T
+	if len(raw) > 0 {
+		if err := decodeBody(res.Header.Get("Content-Type"), raw, &target); err != nil {
+			return nil, err
+		}
+	}
+
+	return &target, nil
+}
+
+// decodeBody 根据Content-Type解析响应体：无法识别时按json解析
+func decodeBody(contentType string, body []byte, target any) error {
+	if isXmlMediaType(contentType) {
+		if err := xml.Unmarshal(body, target); err != nil {
+			return UnmarshalXmlErr.Wrap(err)
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return UnmarshalJsonErr.Wrap(err)
+	}
+
+	return nil
+}
+
+// isJsonMediaType 是否json类型：application/json、application/*+json
+func isJsonMediaType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	return mediaType == ContentTypes[ContentTypeJson] || strings.HasSuffix(mediaType, "+json")
+}
+
+// isXmlMediaType 是否xml类型：application/xml、text/xml、application/*+xml
+func isXmlMediaType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	return mediaType == ContentTypes[ContentTypeXml] || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
@@ -0,0 +1,344 @@
+package httpClient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// HarLogger HAR 1.2日志：作为拦截器记录每次请求的耗时、请求与响应，可在浏览器开发者工具中导入查看
+	HarLogger struct {
+		filename   string
+		entries    []HarEntry
+		maxEntries int
+		mu         sync.Mutex
+	}
+
+	// HarLog HAR文件
+	HarLog struct {
+		Log struct {
+			Version string     `json:"version"`
+			Creator HarCreator `json:"creator"`
+			Entries []HarEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	// HarCreator 日志创建者
+	HarCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	// HarEntry 一次请求记录
+	HarEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         HarRequest  `json:"request"`
+		Response        HarResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         HarTimings  `json:"timings"`
+		ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+
+	// HarRequest 请求
+	HarRequest struct {
+		Method      string         `json:"method"`
+		Url         string         `json:"url"`
+		HttpVersion string         `json:"httpVersion"`
+		Cookies     []HarNameValue `json:"cookies"`
+		Headers     []HarNameValue `json:"headers"`
+		QueryString []HarNameValue `json:"queryString"`
+		PostData    *HarPostData   `json:"postData,omitempty"`
+		HeadersSize int64          `json:"headersSize"`
+		BodySize    int64          `json:"bodySize"`
+	}
+
+	// HarResponse 响应
+	HarResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HttpVersion string         `json:"httpVersion"`
+		Cookies     []HarNameValue `json:"cookies"`
+		Headers     []HarNameValue `json:"headers"`
+		Content     HarContent     `json:"content"`
+		RedirectUrl string         `json:"redirectURL"`
+		HeadersSize int64          `json:"headersSize"`
+		BodySize    int64          `json:"bodySize"`
+	}
+
+	// HarNameValue 名称与值
+	HarNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// HarPostData 请求体
+	HarPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	// HarContent 响应体
+	HarContent struct {
+		Size     int64  `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Encoding string `json:"encoding,omitempty"`
+	}
+
+	// HarTimings 各阶段耗时（毫秒），不适用的阶段为-1
+	HarTimings struct {
+		Blocked float64 `json:"blocked"`
+		Dns     float64 `json:"dns"`
+		Connect float64 `json:"connect"`
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+		Ssl     float64 `json:"ssl"`
+	}
+)
+
+var HarLoggerApp HarLogger
+
+// New 实例化：HAR日志，记录保存在内存中，调用Save或Close时写入文件，filename为空时不写入文件
+func (*HarLogger) New(filename string) *HarLogger { return &HarLogger{filename: filename} }
+
+// SetMaxEntries 设置最多保留的记录数：超出时丢弃最早的记录，0表示不限制
+func (my *HarLogger) SetMaxEntries(maxEntries int) *HarLogger {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.maxEntries = maxEntries
+	my.trim()
+
+	return my
+}
+
+// GetEntries 获取全部请求记录
+func (my *HarLogger) GetEntries() []HarEntry {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	return slices.Clone(my.entries)
+}
+
+// Clean 清空请求记录
+func (my *HarLogger) Clean() *HarLogger {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.entries = nil
+
+	return my
+}
+
+// Save 写入HAR文件：写入当前全部记录
+func (my *HarLogger) Save() error {
+	if my.filename == "" {
+		return nil
+	}
+
+	var har HarLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = HarCreator{Name: "nova/httpClient", Version: "1.0"}
+	har.Log.Entries = my.GetEntries()
+	if har.Log.Entries == nil {
+		har.Log.Entries = []HarEntry{}
+	}
+
+	content, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return HarErr.Wrap(err)
+	}
+
+	if err = os.WriteFile(my.filename, content, 0644); err != nil {
+		return HarErr.Wrap(err)
+	}
+
+	return nil
+}
+
+// Close 写入HAR文件并清空记录
+func (my *HarLogger) Close() error {
+	if err := my.Save(); err != nil {
+		return err
+	}
+	my.Clean()
+
+	return nil
+}
+
+// Record 记录拦截器：响应体会被完整读取到内存，记录保存在内存中
+func (my *HarLogger) Record() Interceptor {
+	return func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+		if isCurlExport(req) {
+			return next(req)
+		}
+
+		timer := newRequestTracer()
+
+		requestBody, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := HarEntry{StartedDateTime: timer.start.Format(time.RFC3339Nano), Request: harRequest(req, requestBody)}
+
+		res, err := next(req.WithContext(httptrace.WithClientTrace(req.Context(), timer.trace())))
+		if err != nil {
+			entry.Comment = err.Error()
+			entry.Response = HarResponse{Cookies: []HarNameValue{}, Headers: []HarNameValue{}, HeadersSize: -1, BodySize: -1}
+			my.append(entry, timer, time.Now())
+			return nil, err
+		}
+
+		responseBody, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		end := time.Now()
+		if err != nil {
+			return nil, hc.wrapReadErr(err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+		entry.Response = harResponse(res, responseBody)
+		my.append(entry, timer, end)
+
+		return res, nil
+	}
+}
+
+// append 追加记录
+func (my *HarLogger) append(entry HarEntry, timer *requestTracer, end time.Time) {
+	entry.Timings, entry.Time = timer.harTimings(end)
+	entry.ServerIPAddress = timer.ip()
+
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.entries = append(my.entries, entry)
+	my.trim()
+}
+
+// trim 丢弃超出数量的最早记录：调用方需持有锁
+func (my *HarLogger) trim() {
+	if my.maxEntries > 0 && len(my.entries) > my.maxEntries {
+		my.entries = slices.Delete(my.entries, 0, len(my.entries)-my.maxEntries)
+	}
+}
+
+// harRequest 转换请求
+func harRequest(req *http.Request, body []byte) HarRequest {
+	request := HarRequest{
+		Method:      req.Method,
+		Url:         req.URL.String(),
+		HttpVersion: "HTTP/1.1",
+		Cookies:     harCookies(req.Cookies()),
+		Headers:     harHeaders(req.Header),
+		QueryString: []HarNameValue{},
+		HeadersSize: -1,
+		BodySize:    int64(len(body)),
+	}
+
+	query := req.URL.Query()
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[key] {
+			request.QueryString = append(request.QueryString, HarNameValue{Name: key, Value: value})
+		}
+	}
+
+	if len(body) > 0 {
+		request.PostData = &HarPostData{MimeType: req.Header.Get("Content-Type"), Text: string(body)}
+	}
+
+	return request
+}
+
+// harResponse 转换响应
+func harResponse(res *http.Response, body []byte) HarResponse {
+	response := HarResponse{
+		Status:      res.StatusCode,
+		StatusText:  http.StatusText(res.StatusCode),
+		HttpVersion: res.Proto,
+		Cookies:     harCookies(res.Cookies()),
+		Headers:     harHeaders(res.Header),
+		RedirectUrl: res.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    int64(len(body)),
+		Content:     HarContent{Size: int64(len(body)), MimeType: res.Header.Get("Content-Type")},
+	}
+
+	if utf8.Valid(body) {
+		response.Content.Text = string(body)
+	} else {
+		response.Content.Text, response.Content.Encoding = base64.StdEncoding.EncodeToString(body), "base64"
+	}
+
+	return response
+}
+
+// harHeaders 转换请求头
+func harHeaders(header http.Header) []HarNameValue {
+	headers := []HarNameValue{}
+	for _, key := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[key] {
+			headers = append(headers, HarNameValue{Name: key, Value: value})
+		}
+	}
+
+	return headers
+}
+
+// harCookies 转换cookie
+func harCookies(cookies []*http.Cookie) []HarNameValue {
+	values := []HarNameValue{}
+	for _, cookie := range cookies {
+		values = append(values, HarNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	return values
+}
+
+// harTimings 计算HAR各阶段耗时及总耗时
+func (my *requestTracer) harTimings(end time.Time) (HarTimings, float64) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	var (
+		timings = HarTimings{Blocked: -1, Dns: -1, Connect: -1, Ssl: -1, Send: 0, Wait: 0, Receive: 0}
+		ms      = func(from, to time.Time) float64 {
+			if from.IsZero() || to.IsZero() || to.Before(from) {
+				return -1
+			}
+			return float64(to.Sub(from).Microseconds()) / 1000
+		}
+	)
+
+	timings.Dns = ms(my.dnsStart, my.dnsDone)
+	timings.Connect = ms(my.connectStart, my.connectDone)
+	timings.Ssl = ms(my.tlsStart, my.tlsDone)
+	if timings.Connect >= 0 && timings.Ssl >= 0 {
+		timings.Connect += timings.Ssl // HAR中connect包含ssl
+	}
+
+	if blocked := ms(my.start, my.gotConn); blocked >= 0 {
+		timings.Blocked = max(blocked-max(timings.Dns, 0)-max(timings.Connect, 0), 0)
+	}
+	timings.Send = max(ms(my.gotConn, my.wroteRequest), 0)
+	timings.Wait = max(ms(my.wroteRequest, my.firstByte), 0)
+	timings.Receive = max(ms(my.firstByte, end), 0)
+
+	total := max(timings.Blocked, 0) + max(timings.Dns, 0) + max(timings.Connect, 0) + timings.Send + timings.Wait + timings.Receive
+
+	return timings, total
+}
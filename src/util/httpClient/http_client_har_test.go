@@ -0,0 +1,105 @@
+package httpClient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test16CurlAndHar(t *testing.T) {
+	server := newEchoServer()
+	defer server.Close()
+
+	t.Run("导出curl命令", func(t *testing.T) {
+		curl, err := NewPost("http://127.0.0.1/api").SetHeaders(map[string][]string{"X-Name": {"it's"}}).SetJsonBody(map[string]string{"a": "b"}).ToCurl()
+		if err != nil {
+			t.Fatalf("导出失败：%v", err)
+		}
+
+		if expect := `curl -X POST 'http://127.0.0.1/api' -H 'Content-Type: application/json' -H 'X-Name: it'\''s' --data-raw '{"a":"b"}'`; curl != expect {
+			t.Errorf("导出错误：\n%s\n%s", curl, expect)
+		}
+	})
+
+	t.Run("导出curl命令：包含拦截器、令牌与cookie，不修改客户端", func(t *testing.T) {
+		session := HttpClientSessionApp.New().SetBaseUrl("http://127.0.0.1")
+		session.SetCookies("/", []*http.Cookie{{Name: "sid", Value: "abc"}})
+
+		hc := session.Put("/api").
+			SetPlainBody("hello").
+			SetRequestEncoding(ContentEncodingGzip).
+			SetTokenSource(NewStaticTokenSource("token")).
+			AppendInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+				req.Header.Set("X-Trace", "1")
+				return next(req)
+			})
+
+		curl, err := hc.ToCurl()
+		if err != nil {
+			t.Fatalf("导出失败：%v", err)
+		}
+
+		if expect := `curl -X PUT 'http://127.0.0.1/api' -H 'Authorization: Bearer token' -H 'Content-Type: text/plain' -H 'Cookie: sid=abc' -H 'X-Trace: 1' --data-raw 'hello'`; curl != expect {
+			t.Errorf("导出错误：\n%s\n%s", curl, expect)
+		}
+		if hc.GetRequest() != nil || hc.isReady {
+			t.Errorf("导出不应修改客户端")
+		}
+	})
+
+	t.Run("导出curl命令：拦截器短路请求", func(t *testing.T) {
+		_, err := NewGet("http://127.0.0.1/api").AppendInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+		}).ToCurl()
+		if !errors.Is(err, &InterceptErr) {
+			t.Errorf("期望拦截错误，实际：%v", err)
+		}
+	})
+
+	t.Run("HAR日志", func(t *testing.T) {
+		var (
+			filename = filepath.Join(t.TempDir(), "requests.har")
+			logger   = HarLoggerApp.New(filename)
+		)
+
+		hc := NewPost(server.URL + "?id=1").SetPlainBody("hello").AppendInterceptors(logger.Record()).Send()
+		if hc.Err != nil || string(hc.GetResponseRawBody()) != "POST /?id=1" {
+			t.Fatalf("请求失败：%v", hc.Err)
+		}
+
+		if _, err := os.Stat(filename); !os.IsNotExist(err) {
+			t.Errorf("未调用Save时不应写入文件")
+		}
+		if err := logger.Save(); err != nil {
+			t.Fatalf("写入失败：%v", err)
+		}
+
+		var har HarLog
+		content, _ := os.ReadFile(filename)
+		if err := json.Unmarshal(content, &har); err != nil || har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+			t.Fatalf("HAR文件错误：%v %s", err, content)
+		}
+
+		entry := har.Log.Entries[0]
+		if entry.Request.Method != http.MethodPost || entry.Request.PostData == nil || entry.Request.PostData.Text != "hello" || len(entry.Request.QueryString) != 1 {
+			t.Errorf("请求记录错误：%+v", entry.Request)
+		}
+		if entry.Response.Status != http.StatusOK || entry.Response.Content.Text != "POST /?id=1" || entry.Time <= 0 || entry.Timings.Wait < 0 {
+			t.Errorf("响应记录错误：%+v %+v", entry.Response, entry.Timings)
+		}
+	})
+
+	t.Run("HAR日志：最多保留的记录数", func(t *testing.T) {
+		logger := HarLoggerApp.New("").SetMaxEntries(2)
+		for _, path := range []string{"/1", "/2", "/3"} {
+			NewGet(server.URL + path).AppendInterceptors(logger.Record()).Send()
+		}
+
+		if entries := logger.GetEntries(); len(entries) != 2 || entries[0].Request.Url != server.URL+"/2" {
+			t.Errorf("记录错误：%+v", entries)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package httpClient
+
+import (
+	"net/http"
+	"sync"
+)
+
+type (
+	// InterceptorNext 拦截器链中的下一个处理者
+	InterceptorNext func(req *http.Request) (*http.Response, error)
+
+	// Interceptor 拦截器：调用next前可修改请求，调用next后可修改响应，不调用next则短路请求
+	Interceptor func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error)
+)
+
+var (
+	globalInterceptors   []Interceptor
+	globalInterceptorsMu sync.RWMutex
+)
+
+// AppendGlobalInterceptors 追加全局拦截器（作用于所有http客户端，先于客户端拦截器执行）
+func AppendGlobalInterceptors(interceptors ...Interceptor) {
+	globalInterceptorsMu.Lock()
+	defer globalInterceptorsMu.Unlock()
+
+	globalInterceptors = append(globalInterceptors, interceptors...)
+}
+
+// CleanGlobalInterceptors 清空全局拦截器
+func CleanGlobalInterceptors() {
+	globalInterceptorsMu.Lock()
+	defer globalInterceptorsMu.Unlock()
+
+	globalInterceptors = nil
+}
+
+// getGlobalInterceptors 获取全局拦截器副本
+func getGlobalInterceptors() []Interceptor {
+	globalInterceptorsMu.RLock()
+	defer globalInterceptorsMu.RUnlock()
+
+	return append([]Interceptor{}, globalInterceptors...)
+}
+
+// AppendInterceptors 追加拦截器
+func (my *HttpClient) AppendInterceptors(interceptors ...Interceptor) *HttpClient {
+	my.interceptors = append(my.interceptors, interceptors...)
+
+	return my
+}
+
+// SetInterceptors 设置拦截器
+func (my *HttpClient) SetInterceptors(interceptors []Interceptor) *HttpClient {
+	my.interceptors = interceptors
+
+	return my
+}
+
+// intercept 通过拦截器链执行请求：全局拦截器 -> 客户端拦截器 -> 令牌 -> terminal
+func (my *HttpClient) intercept(req *http.Request, terminal InterceptorNext) (*http.Response, error) {
+	var (
+		interceptors = append(getGlobalInterceptors(), my.interceptors...)
+		next         = terminal
+	)
+
+	if my.tokenSource != nil {
+		interceptors = append(interceptors, tokenInterceptor(my.tokenSource))
+	}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, n := interceptors[i], next
+		next = func(req *http.Request) (*http.Response, error) { return interceptor(my, req, n) }
+	}
+
+	res, err := next(req)
+	if err == nil && res == nil {
+		err = InterceptErr.New("未返回响应")
+	}
+
+	return res, err
+}
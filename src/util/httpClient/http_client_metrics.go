@@ -0,0 +1,248 @@
+package httpClient
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// ClientMetrics 客户端指标：按host统计请求数、错误数与耗时分布，可输出为Prometheus文本格式
+	ClientMetrics struct {
+		namespace string
+		buckets   []float64
+		hosts     map[string]*hostMetrics
+		mu        sync.RWMutex
+	}
+
+	// hostMetrics 单个host的指标
+	hostMetrics struct {
+		codes        map[string]uint64
+		errors       uint64
+		bucketCounts []uint64
+		sum          float64
+		count        uint64
+	}
+
+	// HostStats 单个host的统计快照
+	HostStats struct {
+		Host    string
+		Count   uint64
+		Errors  uint64            // 请求错误或状态码>=500
+		Codes   map[string]uint64 // 状态码 => 次数，请求错误时状态码为0
+		Sum     time.Duration
+		buckets []float64
+		counts  []uint64
+	}
+)
+
+var (
+	clientMetricsIns  *ClientMetrics
+	clientMetricsOnce sync.Once
+	ClientMetricsApp  ClientMetrics
+
+	// DefaultMetricsBuckets 默认耗时分布区间（秒），与Prometheus默认值一致
+	DefaultMetricsBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+// New 实例化：客户端指标
+func (*ClientMetrics) New() *ClientMetrics {
+	return &ClientMetrics{namespace: "http_client", buckets: DefaultMetricsBuckets, hosts: map[string]*hostMetrics{}}
+}
+
+// Once 单例化：客户端指标
+func (*ClientMetrics) Once() *ClientMetrics {
+	clientMetricsOnce.Do(func() { clientMetricsIns = ClientMetricsApp.New() })
+
+	return clientMetricsIns
+}
+
+// SetNamespace 设置指标名前缀，默认：http_client
+func (my *ClientMetrics) SetNamespace(namespace string) *ClientMetrics {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.namespace = namespace
+
+	return my
+}
+
+// SetBuckets 设置耗时分布区间（秒，升序）：会清空已有统计
+func (my *ClientMetrics) SetBuckets(buckets ...float64) *ClientMetrics {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.buckets = slices.Sorted(slices.Values(buckets))
+	my.hosts = map[string]*hostMetrics{}
+
+	return my
+}
+
+// Reset 清空统计
+func (my *ClientMetrics) Reset() *ClientMetrics {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.hosts = map[string]*hostMetrics{}
+
+	return my
+}
+
+// Observe 记录一次请求
+func (my *ClientMetrics) Observe(host string, res *http.Response, err error, duration time.Duration) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	metrics, ok := my.hosts[host]
+	if !ok {
+		metrics = &hostMetrics{codes: map[string]uint64{}, bucketCounts: make([]uint64, len(my.buckets))}
+		my.hosts[host] = metrics
+	}
+
+	code := "0"
+	if res != nil {
+		code = strconv.Itoa(res.StatusCode)
+	}
+	metrics.codes[code]++
+	if err != nil || res == nil || res.StatusCode >= http.StatusInternalServerError {
+		metrics.errors++
+	}
+
+	seconds := duration.Seconds()
+	for idx, bucket := range my.buckets {
+		if seconds <= bucket {
+			metrics.bucketCounts[idx]++
+		}
+	}
+	metrics.sum += seconds
+	metrics.count++
+}
+
+// Stats 获取指定host的统计快照
+func (my *ClientMetrics) Stats(host string) (HostStats, bool) {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	metrics, ok := my.hosts[host]
+	if !ok {
+		return HostStats{Host: host}, false
+	}
+
+	return HostStats{
+		Host:    host,
+		Count:   metrics.count,
+		Errors:  metrics.errors,
+		Codes:   maps.Clone(metrics.codes),
+		Sum:     time.Duration(metrics.sum * float64(time.Second)),
+		buckets: slices.Clone(my.buckets),
+		counts:  slices.Clone(metrics.bucketCounts),
+	}, true
+}
+
+// Hosts 获取全部host
+func (my *ClientMetrics) Hosts() []string {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(my.hosts))
+}
+
+// WriteTo 输出Prometheus文本格式
+func (my *ClientMetrics) WriteTo(w io.Writer) (int64, error) {
+	var builder strings.Builder
+
+	my.mu.RLock()
+	var (
+		ns    = my.namespace
+		hosts = slices.Sorted(maps.Keys(my.hosts))
+	)
+
+	fmt.Fprintf(&builder, "# HELP %s_requests_total Total number of outbound requests.\n# TYPE %s_requests_total counter\n", ns, ns)
+	for _, host := range hosts {
+		codes := my.hosts[host].codes
+		for _, code := range slices.Sorted(maps.Keys(codes)) {
+			fmt.Fprintf(&builder, "%s_requests_total{host=%s,code=%q} %d\n", ns, quoteLabel(host), code, codes[code])
+		}
+	}
+
+	fmt.Fprintf(&builder, "# HELP %s_request_errors_total Total number of failed outbound requests (error or status >= 500).\n# TYPE %s_request_errors_total counter\n", ns, ns)
+	for _, host := range hosts {
+		fmt.Fprintf(&builder, "%s_request_errors_total{host=%s} %d\n", ns, quoteLabel(host), my.hosts[host].errors)
+	}
+
+	fmt.Fprintf(&builder, "# HELP %s_request_duration_seconds Outbound request latency until response headers.\n# TYPE %s_request_duration_seconds histogram\n", ns, ns)
+	for _, host := range hosts {
+		metrics := my.hosts[host]
+		for idx, bucket := range my.buckets {
+			fmt.Fprintf(&builder, "%s_request_duration_seconds_bucket{host=%s,le=%q} %d\n", ns, quoteLabel(host), strconv.FormatFloat(bucket, 'f', -1, 64), metrics.bucketCounts[idx])
+		}
+		fmt.Fprintf(&builder, "%s_request_duration_seconds_bucket{host=%s,le=\"+Inf\"} %d\n", ns, quoteLabel(host), metrics.count)
+		fmt.Fprintf(&builder, "%s_request_duration_seconds_sum{host=%s} %s\n", ns, quoteLabel(host), strconv.FormatFloat(metrics.sum, 'f', -1, 64))
+		fmt.Fprintf(&builder, "%s_request_duration_seconds_count{host=%s} %d\n", ns, quoteLabel(host), metrics.count)
+	}
+	my.mu.RUnlock()
+
+	n, err := io.WriteString(w, builder.String())
+
+	return int64(n), err
+}
+
+// Handler 指标采集接口
+func (my *ClientMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = my.WriteTo(w)
+	})
+}
+
+// ErrorRate 错误率
+func (my HostStats) ErrorRate() float64 {
+	if my.Count == 0 {
+		return 0
+	}
+
+	return float64(my.Errors) / float64(my.Count)
+}
+
+// Percentile 根据耗时分布估算分位数（0-1）：在区间内线性插值，超出最大区间时返回最大区间上限
+func (my HostStats) Percentile(q float64) time.Duration {
+	if my.Count == 0 || len(my.buckets) == 0 {
+		return 0
+	}
+
+	var (
+		rank       = q * float64(my.Count)
+		lower      float64
+		lowerCount uint64
+	)
+	for idx, upper := range my.buckets {
+		if count := my.counts[idx]; float64(count) >= rank {
+			if count == lowerCount {
+				return time.Duration(upper * float64(time.Second))
+			}
+			seconds := lower + (upper-lower)*(rank-float64(lowerCount))/float64(count-lowerCount)
+			return time.Duration(seconds * float64(time.Second))
+		}
+		lower, lowerCount = upper, my.counts[idx]
+	}
+
+	return time.Duration(my.buckets[len(my.buckets)-1] * float64(time.Second))
+}
+
+// quoteLabel 转义标签值
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// SetMetrics 设置客户端指标：每次发送（包括重试）记录一次
+func (my *HttpClient) SetMetrics(metrics *ClientMetrics) *HttpClient {
+	my.metrics = metrics
+
+	return my
+}
@@ -0,0 +1,72 @@
+package httpClient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test19Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		metrics = ClientMetricsApp.New().SetNamespace("nova_http")
+		host    = server.Listener.Addr().String()
+	)
+
+	t.Run("请求耗时", func(t *testing.T) {
+		hc := NewGet(server.URL).SetMetrics(metrics).Send()
+		if hc.Err != nil {
+			t.Fatalf("请求失败：%v", hc.Err)
+		}
+
+		timing := hc.GetTiming()
+		if timing == nil || timing.Total <= 0 || timing.TimeToFirstByte <= 0 || timing.TcpConnect <= 0 || timing.RemoteAddr != host {
+			t.Errorf("耗时错误：%+v", timing)
+		}
+
+		if timing := NewGet(server.URL).SetMetrics(metrics).Send().GetTiming(); timing == nil || !timing.ConnReused {
+			t.Errorf("期望复用连接：%+v", timing)
+		}
+	})
+
+	t.Run("按host统计", func(t *testing.T) {
+		NewGet(server.URL + "/error").SetMetrics(metrics).Send()
+
+		stats, ok := metrics.Stats(host)
+		if !ok || stats.Count != 3 || stats.Errors != 1 || stats.Codes["200"] != 2 || stats.Codes["500"] != 1 {
+			t.Fatalf("统计错误：%+v", stats)
+		}
+		if rate := stats.ErrorRate(); rate < 0.33 || rate > 0.34 {
+			t.Errorf("错误率错误：%f", rate)
+		}
+		if p99 := stats.Percentile(0.99); p99 <= 0 {
+			t.Errorf("分位数错误：%s", p99)
+		}
+	})
+
+	t.Run("Prometheus文本格式", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		body, _ := io.ReadAll(recorder.Body)
+		for _, expect := range []string{
+			`# TYPE nova_http_request_duration_seconds histogram`,
+			`nova_http_requests_total{host="` + host + `",code="200"} 2`,
+			`nova_http_request_errors_total{host="` + host + `"} 1`,
+			`nova_http_request_duration_seconds_bucket{host="` + host + `",le="+Inf"} 3`,
+			`nova_http_request_duration_seconds_count{host="` + host + `"} 3`,
+		} {
+			if !strings.Contains(string(body), expect) {
+				t.Errorf("缺少指标：%s\n%s", expect, body)
+			}
+		}
+	})
+}
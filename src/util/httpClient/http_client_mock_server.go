@@ -0,0 +1,272 @@
+package httpClient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// MockServer 模拟服务：用于单元测试，按期望匹配请求并返回预设响应
+	MockServer struct {
+		server       *httptest.Server
+		expectations []*MockExpectation
+		unexpected   []string
+		mu           sync.Mutex
+	}
+
+	// MockExpectation 请求期望
+	MockExpectation struct {
+		server     *MockServer
+		err        error
+		method     string
+		path       string
+		queries    map[string]string
+		headers    map[string]string
+		body       *string
+		jsonBody   any
+		times      int // 小于0表示至少一次
+		calls      int
+		status     int
+		resHeaders http.Header
+		resBody    []byte
+		delay      time.Duration
+	}
+
+	// MockTestingT 测试对象：*testing.T满足该接口
+	MockTestingT interface {
+		Helper()
+		Errorf(format string, args ...any)
+	}
+)
+
+var MockServerApp MockServer
+
+// New 实例化：模拟服务，创建后立即启动，使用完毕需调用Close
+func (*MockServer) New() *MockServer {
+	mock := &MockServer{}
+	mock.server = httptest.NewServer(http.HandlerFunc(mock.serve))
+
+	return mock
+}
+
+// URL 服务地址
+func (my *MockServer) URL() string { return my.server.URL }
+
+// Close 关闭服务
+func (my *MockServer) Close() { my.server.Close() }
+
+// Expect 添加请求期望：默认期望至少调用一次，返回200
+func (my *MockServer) Expect(method, path string) *MockExpectation {
+	expectation := &MockExpectation{
+		server:     my,
+		method:     method,
+		path:       path,
+		queries:    map[string]string{},
+		headers:    map[string]string{},
+		times:      -1,
+		status:     http.StatusOK,
+		resHeaders: http.Header{},
+	}
+
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.expectations = append(my.expectations, expectation)
+
+	return expectation
+}
+
+// Verify 校验全部期望是否满足，且没有未预期的请求
+func (my *MockServer) Verify() error {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	var errs []error
+	for _, expectation := range my.expectations {
+		switch {
+		case expectation.err != nil:
+			errs = append(errs, expectation.err)
+		case expectation.times < 0 && expectation.calls == 0:
+			errs = append(errs, MockExpectationErr.New(fmt.Sprintf("%s %s 未被调用", expectation.method, expectation.path)))
+		case expectation.times >= 0 && expectation.calls != expectation.times:
+			errs = append(errs, MockExpectationErr.New(fmt.Sprintf("%s %s 期望调用%d次，实际%d次", expectation.method, expectation.path, expectation.times, expectation.calls)))
+		}
+	}
+
+	for _, request := range my.unexpected {
+		errs = append(errs, MockExpectationErr.New("未预期的请求："+request))
+	}
+
+	return errors.Join(errs...)
+}
+
+// AssertExpectations 在测试中校验全部期望
+func (my *MockServer) AssertExpectations(t MockTestingT) {
+	t.Helper()
+
+	if err := my.Verify(); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+// serve 处理请求
+func (my *MockServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	expectation := my.match(r, body)
+	if expectation == nil {
+		http.Error(w, "mock: 未预期的请求 "+r.Method+" "+r.URL.RequestURI(), http.StatusNotImplemented)
+		return
+	}
+
+	if expectation.delay > 0 {
+		select {
+		case <-time.After(expectation.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for key, values := range expectation.resHeaders {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(expectation.status)
+	_, _ = w.Write(expectation.resBody)
+}
+
+// match 查找匹配的期望并计数：已达到调用次数的期望不再匹配
+func (my *MockServer) match(r *http.Request, body []byte) *MockExpectation {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	for _, expectation := range my.expectations {
+		if (expectation.times < 0 || expectation.calls < expectation.times) && expectation.matches(r, body) {
+			expectation.calls++
+			return expectation
+		}
+	}
+
+	my.unexpected = append(my.unexpected, r.Method+" "+r.URL.RequestURI())
+
+	return nil
+}
+
+// WithQuery 期望包含请求参数
+func (my *MockExpectation) WithQuery(key, value string) *MockExpectation {
+	my.queries[key] = value
+
+	return my
+}
+
+// WithHeader 期望包含请求头
+func (my *MockExpectation) WithHeader(key, value string) *MockExpectation {
+	my.headers[key] = value
+
+	return my
+}
+
+// WithBody 期望请求体完全一致
+func (my *MockExpectation) WithBody(body string) *MockExpectation {
+	my.body = &body
+
+	return my
+}
+
+// WithJsonBody 期望请求体为语义相同的json（忽略字段顺序与空白）
+func (my *MockExpectation) WithJsonBody(body any) *MockExpectation {
+	my.jsonBody = body
+
+	return my
+}
+
+// Times 期望调用的次数：达到次数后不再匹配
+func (my *MockExpectation) Times(times int) *MockExpectation {
+	my.times = times
+
+	return my
+}
+
+// Reply 设置响应状态码与响应体
+func (my *MockExpectation) Reply(status int, body []byte) *MockExpectation {
+	my.status, my.resBody = status, body
+
+	return my
+}
+
+// ReplyJson 设置json响应
+func (my *MockExpectation) ReplyJson(status int, body any) *MockExpectation {
+	content, err := json.Marshal(body)
+	if err != nil {
+		my.err = SetJsonBodyErr.Wrap(err)
+	}
+
+	my.resHeaders.Set("Content-Type", ContentTypes[ContentTypeJson])
+
+	return my.Reply(status, content)
+}
+
+// ReplyHeader 设置响应头
+func (my *MockExpectation) ReplyHeader(key, value string) *MockExpectation {
+	my.resHeaders.Add(key, value)
+
+	return my
+}
+
+// Delay 设置响应延迟
+func (my *MockExpectation) Delay(delay time.Duration) *MockExpectation {
+	my.delay = delay
+
+	return my
+}
+
+// Calls 获取已调用次数
+func (my *MockExpectation) Calls() int {
+	my.server.mu.Lock()
+	defer my.server.mu.Unlock()
+
+	return my.calls
+}
+
+// matches 是否匹配请求
+func (my *MockExpectation) matches(r *http.Request, body []byte) bool {
+	if r.Method != my.method || r.URL.Path != my.path {
+		return false
+	}
+
+	query := r.URL.Query()
+	for key, value := range my.queries {
+		if query.Get(key) != value {
+			return false
+		}
+	}
+
+	for key, value := range my.headers {
+		if r.Header.Get(key) != value {
+			return false
+		}
+	}
+
+	if my.body != nil && string(body) != *my.body {
+		return false
+	}
+
+	if my.jsonBody != nil {
+		var actual, expect any
+
+		content, err := json.Marshal(my.jsonBody)
+		if err != nil || json.Unmarshal(content, &expect) != nil || json.Unmarshal(body, &actual) != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(actual, expect)
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package httpClient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test20MockServer(t *testing.T) {
+	t.Run("模拟服务：匹配期望并返回预设响应", func(t *testing.T) {
+		mock := MockServerApp.New()
+		defer mock.Close()
+
+		login := mock.Expect(http.MethodPost, "/login").
+			WithHeader("X-App", "nova").
+			WithJsonBody(map[string]any{"name": "nova", "age": 1}).
+			ReplyJson(http.StatusCreated, map[string]string{"token": "abc"}).
+			Times(1)
+		mock.Expect(http.MethodGet, "/users").WithQuery("id", "1").Reply(http.StatusOK, []byte("user-1"))
+
+		var body struct{ Token string }
+		hc := NewPost(mock.URL() + "/login").
+			SetHeaders(map[string][]string{"X-App": {"nova"}}).
+			SetBody([]byte(`{"age": 1, "name": "nova"}`)).
+			Send().
+			GetResponseJsonBody(&body)
+		if hc.Err != nil || hc.GetResponse().StatusCode != http.StatusCreated || body.Token != "abc" {
+			t.Errorf("响应错误：%v %s", hc.Err, hc.GetResponseRawBody())
+		}
+
+		if hc = NewGet(mock.URL()+"/users").AddQuery("id", "1").Send(); string(hc.GetResponseRawBody()) != "user-1" {
+			t.Errorf("响应错误：%s", hc.GetResponseRawBody())
+		}
+
+		if login.Calls() != 1 {
+			t.Errorf("调用次数错误：%d", login.Calls())
+		}
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("模拟服务：期望未满足", func(t *testing.T) {
+		mock := MockServerApp.New()
+		defer mock.Close()
+
+		mock.Expect(http.MethodGet, "/ping").Times(2)
+		mock.Expect(http.MethodGet, "/never")
+
+		NewGet(mock.URL() + "/ping").Send()
+		if hc := NewGet(mock.URL() + "/unknown").Send(); hc.GetResponse().StatusCode != http.StatusNotImplemented {
+			t.Errorf("未预期的请求应返回501：%d", hc.GetResponse().StatusCode)
+		}
+
+		if err := mock.Verify(); !errors.Is(err, &MockExpectationErr) {
+			t.Errorf("期望校验失败，实际：%v", err)
+		}
+	})
+
+	t.Run("模拟服务：响应延迟", func(t *testing.T) {
+		mock := MockServerApp.New()
+		defer mock.Close()
+
+		mock.Expect(http.MethodGet, "/slow").Delay(200 * time.Millisecond)
+
+		if hc := NewGet(mock.URL() + "/slow").SetTimeout(50 * time.Millisecond).Send(); !errors.Is(hc.Err, &RequestTimeoutErr) {
+			t.Errorf("期望超时，实际：%v", hc.Err)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package httpClient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	processBar "github.com/schollz/progressbar/v3"
+)
+
+type (
+	// HttpClientMultipart 流式multipart上传：通过io.Pipe边读边发，不在内存中缓存请求体
+	HttpClientMultipart struct {
+		httpClient     *HttpClient
+		boundary       string
+		fields         []multipartField
+		files          []*multipartFile
+		processContent string
+		onProgress     func(written, total int64)
+	}
+
+	// multipartField 文本字段
+	multipartField struct{ name, value string }
+
+	// multipartFile 文件字段
+	multipartFile struct {
+		fieldName   string
+		filename    string
+		contentType string
+		size        int64 // 小于0表示未知
+		open        func() (io.ReadCloser, error)
+		replayable  bool
+	}
+
+	// bodyStream 流式请求体
+	bodyStream struct {
+		open       func() (io.ReadCloser, error)
+		length     int64 // 小于0表示未知，使用分块传输
+		replayable bool  // 可重新打开时支持重试
+	}
+
+	// lazyPipeReader 首次读取时才启动写入协程，未发送的请求不会遗留协程
+	lazyPipeReader struct {
+		write  func(writer io.Writer) error
+		once   sync.Once
+		reader *io.PipeReader
+	}
+
+	// progressWriter 上传进度统计
+	progressWriter struct {
+		writer     io.Writer
+		written    int64
+		total      int64
+		onProgress func(written, total int64)
+		bar        *processBar.ProgressBar
+	}
+)
+
+var (
+	HttpClientMultipartApp HttpClientMultipart
+
+	quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+)
+
+// New 实例化：流式multipart上传
+func (*HttpClientMultipart) New(httpClient *HttpClient) *HttpClientMultipart {
+	return &HttpClientMultipart{httpClient: httpClient, boundary: multipart.NewWriter(io.Discard).Boundary()}
+}
+
+// Multipart 使用流式multipart上传
+func (my *HttpClient) Multipart() *HttpClientMultipart { return HttpClientMultipartApp.New(my) }
+
+// AddField 添加文本字段
+func (my *HttpClientMultipart) AddField(name, value string) *HttpClientMultipart {
+	my.fields = append(my.fields, multipartField{name: name, value: value})
+
+	return my
+}
+
+// AddFields 批量添加文本字段
+func (my *HttpClientMultipart) AddFields(fields map[string]string) *HttpClientMultipart {
+	for name, value := range fields {
+		my.AddField(name, value)
+	}
+
+	return my
+}
+
+// AddFile 添加文件：reader只能读取一次，因此不支持重试；size小于0表示未知，contentType为空时根据文件扩展名判断
+func (my *HttpClientMultipart) AddFile(fieldName, filename, contentType string, reader io.Reader, size int64) *HttpClientMultipart {
+	var opened atomic.Bool
+
+	my.files = append(my.files, &multipartFile{
+		fieldName:   fieldName,
+		filename:    filename,
+		contentType: contentType,
+		size:        size,
+		open: func() (io.ReadCloser, error) {
+			if opened.Swap(true) {
+				return nil, errors.New("文件已读取，无法重复发送：" + filename)
+			}
+			return io.NopCloser(reader), nil
+		},
+	})
+
+	return my
+}
+
+// AddFileFromPath 添加本地文件：发送时才打开文件，支持重试
+func (my *HttpClientMultipart) AddFileFromPath(fieldName, path string) *HttpClientMultipart {
+	stat, err := os.Stat(path)
+	if err != nil {
+		my.httpClient.Err = SetFormBodyErr.Wrap(err)
+		return my
+	}
+
+	my.files = append(my.files, &multipartFile{
+		fieldName:  fieldName,
+		filename:   filepath.Base(path),
+		size:       stat.Size(),
+		open:       func() (io.ReadCloser, error) { return os.Open(path) },
+		replayable: true,
+	})
+
+	return my
+}
+
+// SetProgress 设置上传进度回调：total小于0表示总大小未知
+func (my *HttpClientMultipart) SetProgress(onProgress func(written, total int64)) *HttpClientMultipart {
+	my.onProgress = onProgress
+
+	return my
+}
+
+// SetProcessContent 设置终端进度条标题
+func (my *HttpClientMultipart) SetProcessContent(processContent string) *HttpClientMultipart {
+	my.processContent = processContent
+
+	return my
+}
+
+// Ready 将multipart设置为http客户端的请求体
+func (my *HttpClientMultipart) Ready() *HttpClient {
+	replayable := true
+	for _, file := range my.files {
+		replayable = replayable && file.replayable
+	}
+
+	my.httpClient.requestHeaders["Content-Type"] = []string{"multipart/form-data; boundary=" + my.boundary}
+	my.httpClient.requestBodyStream = &bodyStream{open: my.open, length: my.length(), replayable: replayable}
+
+	return my.httpClient
+}
+
+// Send 发送请求
+func (my *HttpClientMultipart) Send() *HttpClient { return my.Ready().Send() }
+
+// open 打开请求体
+func (my *HttpClientMultipart) open() (io.ReadCloser, error) {
+	total := my.length()
+
+	return &lazyPipeReader{write: func(writer io.Writer) error {
+		progress := &progressWriter{writer: writer, total: total, onProgress: my.onProgress}
+		if my.processContent != "" {
+			progress.bar = processBar.DefaultBytes(total, my.processContent)
+		}
+
+		return my.write(progress, false)
+	}}, nil
+}
+
+// length 计算请求体总长度：存在未知大小的文件时返回-1
+func (my *HttpClientMultipart) length() int64 {
+	var (
+		counter = &progressWriter{writer: io.Discard}
+		size    int64
+	)
+
+	for _, file := range my.files {
+		if file.size < 0 {
+			return -1
+		}
+		size += file.size
+	}
+
+	if err := my.write(counter, true); err != nil {
+		return -1
+	}
+
+	return counter.written + size
+}
+
+// write 写入multipart内容：onlyHeader为true时不写入文件内容，用于计算长度
+func (my *HttpClientMultipart) write(w io.Writer, onlyHeader bool) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(my.boundary); err != nil {
+		return err
+	}
+
+	for _, field := range my.fields {
+		if err := writer.WriteField(field.name, field.value); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range my.files {
+		part, err := writer.CreatePart(file.header())
+		if err != nil {
+			return err
+		}
+		if onlyHeader {
+			continue
+		}
+
+		if err = file.copy(part); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// header 文件字段头
+func (my *multipartFile) header() textproto.MIMEHeader {
+	contentType := my.contentType
+	if contentType == "" {
+		if contentType = mime.TypeByExtension(filepath.Ext(my.filename)); contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(my.fieldName), quoteEscaper.Replace(my.filename)))
+	header.Set("Content-Type", contentType)
+
+	return header
+}
+
+// copy 写入文件内容
+func (my *multipartFile) copy(writer io.Writer) error {
+	reader, err := my.open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(writer, reader)
+
+	return err
+}
+
+// apply 设置请求体长度与重试时重新打开请求体的方法
+func (my *bodyStream) apply(req *http.Request) {
+	if my.length >= 0 {
+		req.ContentLength = my.length
+	}
+
+	if my.replayable {
+		req.GetBody = my.open
+	}
+}
+
+// Read 首次读取时启动写入协程
+func (my *lazyPipeReader) Read(p []byte) (int, error) {
+	my.once.Do(func() {
+		var writer *io.PipeWriter
+
+		my.reader, writer = io.Pipe()
+		go func() { _ = writer.CloseWithError(my.write(writer)) }()
+	})
+
+	return my.reader.Read(p)
+}
+
+// Close 关闭管道：写入协程随之结束
+func (my *lazyPipeReader) Close() error {
+	my.once.Do(func() { my.reader, _ = io.Pipe() })
+
+	return my.reader.Close()
+}
+
+// Write 写入并统计进度
+func (my *progressWriter) Write(p []byte) (int, error) {
+	n, err := my.writer.Write(p)
+
+	my.written += int64(n)
+	if my.bar != nil {
+		_ = my.bar.Add(n)
+	}
+	if my.onProgress != nil && n > 0 {
+		my.onProgress(my.written, my.total)
+	}
+
+	return n, err
+}
@@ -0,0 +1,88 @@
+package httpClient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test15Multipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "length=%d", r.ContentLength)
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(part)
+			_, _ = fmt.Fprintf(w, ";%s:%s:%d", part.FormName(), part.FileName(), len(content))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		dir      = t.TempDir()
+		filename = filepath.Join(dir, "a.bin")
+		content  = bytes.Repeat([]byte("x"), 256*1024)
+	)
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		t.Fatalf("写入文件失败：%v", err)
+	}
+
+	t.Run("流式上传：本地文件与进度", func(t *testing.T) {
+		var written, total int64
+
+		hc := NewPost(server.URL).Multipart().
+			AddField("name", "nova").
+			AddFileFromPath("file", filename).
+			SetProgress(func(w, t int64) { written, total = w, t }).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("上传失败：%v", hc.Err)
+		}
+
+		body := string(hc.GetResponseRawBody())
+		if !strings.HasPrefix(body, fmt.Sprintf("length=%d;", total)) || !strings.Contains(body, ";name::4;file:a.bin:262144") {
+			t.Errorf("响应错误：%s", body)
+		}
+		if total <= int64(len(content)) || written != total {
+			t.Errorf("进度错误：%d/%d", written, total)
+		}
+	})
+
+	t.Run("流式上传：未知大小的reader", func(t *testing.T) {
+		hc := NewPost(server.URL).Multipart().AddFile("file", "b.txt", "", strings.NewReader("hello"), -1).Send()
+		if hc.Err != nil {
+			t.Fatalf("上传失败：%v", hc.Err)
+		}
+		if body := string(hc.GetResponseRawBody()); body != "length=-1;file:b.txt:5" {
+			t.Errorf("响应错误：%s", body)
+		}
+	})
+
+	t.Run("表单数据请求体", func(t *testing.T) {
+		hc := NewPost(server.URL).SetFormDataBody(map[string]string{"name": "nova"}, map[string]string{"a.bin": filename}).Send()
+		if hc.Err != nil {
+			t.Fatalf("上传失败：%v", hc.Err)
+		}
+		if body := string(hc.GetResponseRawBody()); !strings.Contains(body, ";name::4;fileField:a.bin:262144") {
+			t.Errorf("响应错误：%s", body)
+		}
+	})
+}
@@ -1,12 +1,35 @@
 package httpClient
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 )
 
-type Multiple struct {
-	clients []*HttpClient
-}
+type (
+	Multiple struct {
+		clients     []*HttpClient
+		concurrency int
+		failFast    bool
+		timeout     time.Duration
+		ctx         context.Context
+		result      *MultipleResult
+	}
+
+	// MultipleResultItem 批量请求结果项
+	MultipleResultItem struct {
+		Index      int
+		Client     *HttpClient
+		StatusCode int
+		Err        error
+	}
+
+	// MultipleResult 批量请求结果：顺序与添加顺序一致
+	MultipleResult struct {
+		items []MultipleResultItem
+	}
+)
 
 var MultipleApp Multiple
 
@@ -15,7 +38,7 @@ func (*Multiple) New() *Multiple { return NewMultiple() }
 // NewMultiple 实例化：批量请求对象
 //
 //go:fix 推荐使用New方法
-func NewMultiple() *Multiple { return &MultipleApp }
+func NewMultiple() *Multiple { return &Multiple{} }
 
 // Append 添加httpClient对象
 func (my *Multiple) Append(hc *HttpClient) *Multiple {
@@ -31,25 +54,165 @@ func (my *Multiple) SetClients(clients []*HttpClient) *Multiple {
 	return my
 }
 
+// SetConcurrency 设置最大并发数：小于等于0表示不限制
+func (my *Multiple) SetConcurrency(concurrency int) *Multiple {
+	my.concurrency = concurrency
+
+	return my
+}
+
+// SetFailFast 设置快速失败：任一请求出错时取消其余请求
+func (my *Multiple) SetFailFast(failFast bool) *Multiple {
+	my.failFast = failFast
+
+	return my
+}
+
+// SetTimeout 设置整批请求超时
+func (my *Multiple) SetTimeout(timeout time.Duration) *Multiple {
+	my.timeout = timeout
+
+	return my
+}
+
+// SetContext 设置上下文
+func (my *Multiple) SetContext(ctx context.Context) *Multiple {
+	my.ctx = ctx
+
+	return my
+}
+
 // Send 批量发送
-func (my *Multiple) Send() *Multiple {
-	if len(my.clients) > 0 {
-		var wg sync.WaitGroup
-		wg.Add(len(my.clients))
+func (my *Multiple) Send() *Multiple { return my.send(orBackground(my.ctx)) }
 
-		for _, client := range my.clients {
-			go func(client *HttpClient) {
-				defer wg.Done()
+// send 使用上下文批量发送
+func (my *Multiple) send(parent context.Context) *Multiple {
+	var (
+		ctx, cancel = context.WithCancel(parent)
+		items       = make([]MultipleResultItem, len(my.clients))
+		indexes     = make(chan int, len(my.clients))
+		workers     = len(my.clients)
+		wg          sync.WaitGroup
+	)
+	defer cancel()
 
-				client.Send()
-			}(client)
-		}
+	if my.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, my.timeout)
+		defer cancel()
+	}
+
+	if my.concurrency > 0 && my.concurrency < workers {
+		workers = my.concurrency
+	}
+
+	for idx, client := range my.clients {
+		items[idx] = MultipleResultItem{Index: idx, Client: client}
+		indexes <- idx
+	}
+	close(indexes)
+
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				item := &items[idx]
+				if ctx.Err() != nil {
+					item.Err = item.Client.wrapContextErr(ctx.Err())
+					continue
+				}
+
+				my.sendOne(ctx, item)
 
-		wg.Wait()
+				if item.Err != nil && my.failFast {
+					cancel()
+				}
+			}
+		}()
 	}
 
+	wg.Wait()
+
+	my.result = &MultipleResult{items: items}
+
 	return my
 }
 
+// sendOne 使用批量上下文发送单个请求，发送后恢复原上下文
+func (my *Multiple) sendOne(ctx context.Context, item *MultipleResultItem) {
+	var (
+		client          = item.Client
+		clientCtx, stop = mergeContext(client.getContext(), ctx)
+	)
+	defer stop()
+
+	client.SendWithContext(clientCtx)
+
+	item.Err = client.Err
+	if client.response != nil {
+		item.StatusCode = client.response.StatusCode
+	}
+}
+
+// SendWithContext 使用上下文批量发送：上下文仅作用于本次发送
+func (my *Multiple) SendWithContext(ctx context.Context) *Multiple {
+	return my.send(orBackground(ctx))
+}
+
 // GetClients 获取链接池
 func (my *Multiple) GetClients() []*HttpClient { return my.clients }
+
+// GetResult 获取最近一次批量发送的结果
+func (my *Multiple) GetResult() *MultipleResult { return my.result }
+
+// Items 获取全部结果
+func (my *MultipleResult) Items() []MultipleResultItem { return my.items }
+
+// Get 获取指定客户端的结果
+func (my *MultipleResult) Get(client *HttpClient) (MultipleResultItem, bool) {
+	for _, item := range my.items {
+		if item.Client == client {
+			return item, true
+		}
+	}
+
+	return MultipleResultItem{}, false
+}
+
+// Failed 获取失败的结果
+func (my *MultipleResult) Failed() []MultipleResultItem {
+	var items []MultipleResultItem
+	for _, item := range my.items {
+		if item.Err != nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// Succeeded 获取成功的结果
+func (my *MultipleResult) Succeeded() []MultipleResultItem {
+	var items []MultipleResultItem
+	for _, item := range my.items {
+		if item.Err == nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// HasError 是否存在失败的请求
+func (my *MultipleResult) HasError() bool { return len(my.Failed()) > 0 }
+
+// Err 获取聚合错误
+func (my *MultipleResult) Err() error {
+	var errs []error
+	for _, item := range my.Failed() {
+		errs = append(errs, item.Err)
+	}
+
+	return errors.Join(errs...)
+}
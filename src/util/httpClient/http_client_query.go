@@ -0,0 +1,337 @@
+package httpClient
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// QuerySliceFormat 切片参数格式
+	QuerySliceFormat string
+
+	// QueryBuilder 查询参数构造器：支持同名多值参数与结构体编码
+	//
+	// 结构体字段通过query标签指定参数名，如：`query:"page"`、`query:"ids,omitempty,comma"`、`query:"start,unix"`、`query:"-"`；
+	// 标签选项：omitempty（零值忽略）、repeat/comma/brackets（切片格式）、unix/unixmilli（时间格式）
+	QueryBuilder struct {
+		values      url.Values
+		timeFormat  string
+		sliceFormat QuerySliceFormat
+		err         error
+	}
+)
+
+var (
+	QueryBuilderApp QueryBuilder
+
+	QuerySliceRepeat   QuerySliceFormat = "repeat"   // id=1&id=2
+	QuerySliceComma    QuerySliceFormat = "comma"    // id=1,2
+	QuerySliceBrackets QuerySliceFormat = "brackets" // id[]=1&id[]=2
+
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	querySliceFormatSet = map[string]QuerySliceFormat{"repeat": QuerySliceRepeat, "comma": QuerySliceComma, "brackets": QuerySliceBrackets}
+)
+
+// New 实例化：查询参数构造器，时间默认使用RFC3339格式，切片默认使用重复参数名格式
+func (*QueryBuilder) New() *QueryBuilder {
+	return &QueryBuilder{values: url.Values{}, timeFormat: time.RFC3339, sliceFormat: QuerySliceRepeat}
+}
+
+// SetTimeFormat 设置时间格式
+func (my *QueryBuilder) SetTimeFormat(timeFormat string) *QueryBuilder {
+	my.timeFormat = timeFormat
+
+	return my
+}
+
+// SetSliceFormat 设置切片格式
+func (my *QueryBuilder) SetSliceFormat(sliceFormat QuerySliceFormat) *QueryBuilder {
+	my.sliceFormat = sliceFormat
+
+	return my
+}
+
+// Add 追加参数值
+func (my *QueryBuilder) Add(key string, values ...string) *QueryBuilder {
+	my.values[key] = append(my.values[key], values...)
+
+	return my
+}
+
+// Set 设置参数值：替换同名参数
+func (my *QueryBuilder) Set(key string, values ...string) *QueryBuilder {
+	my.values[key] = slices.Clone(values)
+
+	return my
+}
+
+// Del 删除参数
+func (my *QueryBuilder) Del(key string) *QueryBuilder {
+	my.values.Del(key)
+
+	return my
+}
+
+// Merge 合并参数：追加同名参数值
+func (my *QueryBuilder) Merge(values url.Values) *QueryBuilder {
+	for key, vals := range values {
+		my.Add(key, vals...)
+	}
+
+	return my
+}
+
+// Struct 编码结构体（或结构体指针）并追加到参数中
+func (my *QueryBuilder) Struct(v any) *QueryBuilder {
+	if my.err != nil {
+		return my
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return my
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		my.err = QueryErr.New(fmt.Sprintf("不支持的类型：%T", v))
+		return my
+	}
+
+	my.err = my.encodeStruct(value)
+
+	return my
+}
+
+// Values 获取参数
+func (my *QueryBuilder) Values() (url.Values, error) {
+	if my.err != nil {
+		return nil, my.err
+	}
+
+	values := make(url.Values, len(my.values))
+	for key, vals := range my.values {
+		values[key] = slices.Clone(vals)
+	}
+
+	return values, nil
+}
+
+// Encode 编码为查询字符串
+func (my *QueryBuilder) Encode() (string, error) {
+	if my.err != nil {
+		return "", my.err
+	}
+
+	return my.values.Encode(), nil
+}
+
+// encodeStruct 编码结构体字段
+func (my *QueryBuilder) encodeStruct(value reflect.Value) error {
+	for idx := range value.NumField() {
+		var (
+			field      = value.Type().Field(idx)
+			fieldValue = value.Field(idx)
+			tag        = field.Tag.Get("query")
+		)
+
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct && indirectType(field.Type) != timeType {
+			// 匿名结构体字段展开
+			for fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				if err := my.encodeStruct(fieldValue); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if err := my.encodeField(name, fieldValue, strings.Split(options, ",")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeField 编码单个字段
+func (my *QueryBuilder) encodeField(name string, value reflect.Value, options []string) error {
+	var (
+		omitempty   = slices.Contains(options, "omitempty")
+		sliceFormat = my.sliceFormat
+	)
+
+	for _, option := range options {
+		if format, ok := querySliceFormatSet[option]; ok {
+			sliceFormat = format
+		}
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if omitempty && value.IsZero() {
+		return nil
+	}
+
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]string, 0, value.Len())
+		for idx := range value.Len() {
+			item, ok, err := my.format(value.Index(idx), options)
+			if err != nil {
+				return QueryErr.New(fmt.Sprintf("%s：%s", name, err.Error()))
+			}
+			if ok {
+				items = append(items, item)
+			}
+		}
+
+		switch sliceFormat {
+		case QuerySliceComma:
+			if len(items) > 0 {
+				my.Add(name, strings.Join(items, ","))
+			}
+		case QuerySliceBrackets:
+			my.Add(name+"[]", items...)
+		default:
+			my.Add(name, items...)
+		}
+
+		return nil
+	}
+
+	item, ok, err := my.format(value, options)
+	if err != nil {
+		return QueryErr.New(fmt.Sprintf("%s：%s", name, err.Error()))
+	}
+	if ok {
+		my.Add(name, item)
+	}
+
+	return nil
+}
+
+// format 格式化标量值：空指针返回false
+func (my *QueryBuilder) format(value reflect.Value, options []string) (string, bool, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false, nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == timeType {
+		t := value.Interface().(time.Time)
+		switch {
+		case slices.Contains(options, "unix"):
+			return strconv.FormatInt(t.Unix(), 10), true, nil
+		case slices.Contains(options, "unixmilli"):
+			return strconv.FormatInt(t.UnixMilli(), 10), true, nil
+		default:
+			return t.Format(my.timeFormat), true, nil
+		}
+	}
+
+	if value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err == nil, err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), true, nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), true, nil
+		}
+	}
+
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String(), true, nil
+	}
+
+	return "", false, fmt.Errorf("不支持的类型：%s", value.Type())
+}
+
+// indirectType 获取指针指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// AddQuery 追加请求参数：支持同名多值
+func (my *HttpClient) AddQuery(key string, values ...string) *HttpClient {
+	my.requestQueries[key] = append(my.requestQueries[key], values...)
+
+	return my
+}
+
+// AddQueries 批量追加请求参数
+func (my *HttpClient) AddQueries(queries url.Values) *HttpClient {
+	for key, values := range queries {
+		my.AddQuery(key, values...)
+	}
+
+	return my
+}
+
+// SetQueryBuilder 使用查询参数构造器设置请求参数：替换已设置的请求参数
+func (my *HttpClient) SetQueryBuilder(builder *QueryBuilder) *HttpClient {
+	values, err := builder.Values()
+	if err != nil {
+		my.Err = err
+		return my
+	}
+
+	my.requestQueries = values
+
+	return my
+}
+
+// SetQueryStruct 将结构体编码为请求参数并追加，编码规则见QueryBuilder
+func (my *HttpClient) SetQueryStruct(v any) *HttpClient {
+	values, err := QueryBuilderApp.New().Struct(v).Values()
+	if err != nil {
+		my.Err = err
+		return my
+	}
+
+	return my.AddQueries(values)
+}
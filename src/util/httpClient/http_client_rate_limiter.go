@@ -0,0 +1,146 @@
+package httpClient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimiter 客户端限流器：令牌桶算法，按key（默认为请求的host）分别限流，可被多个http客户端共享
+	RateLimiter struct {
+		rate     float64
+		burst    int
+		failFast bool
+		buckets  map[string]*rateBucket
+		mu       sync.Mutex
+	}
+
+	// rateBucket 令牌桶
+	rateBucket struct {
+		tokens   float64
+		updateAt time.Time
+	}
+)
+
+var RateLimiterApp RateLimiter
+
+// New 实例化：客户端限流器，rate为每秒生成的令牌数，burst为桶容量（允许的突发请求数）
+func (*RateLimiter) New(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{rate: rate, burst: max(burst, 1), buckets: map[string]*rateBucket{}}
+}
+
+// SetFailFast 设置快速失败：无可用令牌时直接返回RateLimitErr，默认阻塞等待
+func (my *RateLimiter) SetFailFast(failFast bool) *RateLimiter {
+	my.failFast = failFast
+
+	return my
+}
+
+// Allow 尝试获取令牌：不等待
+func (my *RateLimiter) Allow(key string) bool {
+	_, ok := my.reserve(key, false)
+
+	return ok
+}
+
+// Wait 获取令牌：快速失败模式下无可用令牌时返回RateLimitErr，否则等待至获取令牌或上下文结束
+func (my *RateLimiter) Wait(ctx context.Context, key string) error {
+	wait, ok := my.reserve(key, !my.failFast)
+	if !ok {
+		return RateLimitErr.New(key)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		my.cancel(key)
+		return ctx.Err()
+	}
+}
+
+// reserve 预占令牌：返回需要等待的时间，不允许等待且无可用令牌时返回false
+func (my *RateLimiter) reserve(key string, wait bool) (time.Duration, bool) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := my.buckets[key]
+	if !ok {
+		bucket = &rateBucket{tokens: float64(my.burst), updateAt: now}
+		my.buckets[key] = bucket
+	}
+
+	bucket.tokens = min(float64(my.burst), bucket.tokens+now.Sub(bucket.updateAt).Seconds()*my.rate)
+	bucket.updateAt = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0, true
+	}
+	if !wait || my.rate <= 0 {
+		return 0, false
+	}
+
+	// 预占未来的令牌，按预占顺序依次放行
+	bucket.tokens--
+
+	return time.Duration(-bucket.tokens / my.rate * float64(time.Second)), true
+}
+
+// cancel 归还预占的令牌
+func (my *RateLimiter) cancel(key string) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if bucket, ok := my.buckets[key]; ok {
+		bucket.tokens = min(float64(my.burst), bucket.tokens+1)
+	}
+}
+
+// SetRateLimiter 设置客户端限流器：每次发送（包括重试）前获取令牌
+func (my *HttpClient) SetRateLimiter(rateLimiter *RateLimiter) *HttpClient {
+	my.rateLimiter = rateLimiter
+
+	return my
+}
+
+// SetRateLimitKey 设置限流key：默认使用请求的host
+func (my *HttpClient) SetRateLimitKey(key string) *HttpClient {
+	my.rateLimitKey = key
+
+	return my
+}
+
+// limitRate 获取限流令牌
+func (my *HttpClient) limitRate(req *http.Request) error {
+	if my.rateLimiter == nil {
+		return nil
+	}
+
+	return my.rateLimiter.Wait(req.Context(), my.getRateLimitKey(req))
+}
+
+// refundRate 归还限流令牌：请求未实际发出时（如：熔断）调用
+func (my *HttpClient) refundRate(req *http.Request) {
+	if my.rateLimiter != nil {
+		my.rateLimiter.cancel(my.getRateLimitKey(req))
+	}
+}
+
+// getRateLimitKey 获取限流key：默认使用请求的host
+func (my *HttpClient) getRateLimitKey(req *http.Request) string {
+	if my.rateLimitKey == "" {
+		return req.URL.Host
+	}
+
+	return my.rateLimitKey
+}
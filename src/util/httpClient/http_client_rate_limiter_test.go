@@ -0,0 +1,117 @@
+package httpClient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test14RateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	t.Run("限流：阻塞等待", func(t *testing.T) {
+		var (
+			limiter  = RateLimiterApp.New(20, 2)
+			multiple = MultipleApp.New()
+			startAt  = time.Now()
+		)
+
+		for range 6 {
+			multiple.Append(NewGet(server.URL).SetRateLimiter(limiter))
+		}
+		if err := multiple.Send().GetResult().Err(); err != nil {
+			t.Fatalf("请求失败：%v", err)
+		}
+
+		// 突发2个，其余4个每50ms放行1个
+		if elapsed := time.Since(startAt); elapsed < 180*time.Millisecond {
+			t.Errorf("未限流：%s", elapsed)
+		}
+	})
+
+	t.Run("限流：快速失败", func(t *testing.T) {
+		limiter := RateLimiterApp.New(1, 1).SetFailFast(true)
+
+		if hc := NewGet(server.URL).SetRateLimiter(limiter).SetRateLimitKey("partner").Send(); hc.Err != nil {
+			t.Fatalf("请求失败：%v", hc.Err)
+		}
+		if hc := NewGet(server.URL).SetRateLimiter(limiter).SetRateLimitKey("partner").Send(); !errors.Is(hc.Err, &RateLimitErr) {
+			t.Errorf("期望限流错误，实际：%v", hc.Err)
+		}
+		if hc := NewGet(server.URL).SetRateLimiter(limiter).Send(); hc.Err != nil {
+			t.Errorf("不同key不应限流：%v", hc.Err)
+		}
+	})
+
+	t.Run("限流：半开状态下被限流的请求不影响熔断器", func(t *testing.T) {
+		var (
+			healthy atomic.Bool
+			hits    atomic.Int32
+			server  = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if !healthy.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			target  = server.Listener.Addr().String()
+			breaker = CircuitBreakerApp.New().SetMinRequests(1).SetCooldown(50 * time.Millisecond)
+			limiter = RateLimiterApp.New(0.001, 1).SetFailFast(true)
+		)
+		defer server.Close()
+
+		NewGet(server.URL).SetCircuitBreaker(breaker).Send()
+		time.Sleep(80 * time.Millisecond)
+		healthy.Store(true)
+		limiter.Allow(target) // 耗尽令牌
+
+		hits.Store(0)
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).SetRateLimiter(limiter).Send(); !errors.Is(hc.Err, &RateLimitErr) {
+			t.Fatalf("期望限流错误，实际：%v", hc.Err)
+		}
+		if state := breaker.State(target); state != CircuitStateHalfOpen || hits.Load() != 0 {
+			t.Errorf("被限流的请求不应改变状态：%s %d", state, hits.Load())
+		}
+
+		// 等待限流令牌的请求不占用半开试探名额
+		var (
+			waiting = make(chan error, 1)
+			slow    = RateLimiterApp.New(5, 1)
+		)
+		slow.Allow("slow") // 之后的请求需等待约200ms
+		go func() {
+			waiting <- NewGet(server.URL).SetCircuitBreaker(breaker).SetRateLimiter(slow).SetRateLimitKey("slow").Send().Err
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		if hc := NewGet(server.URL).SetCircuitBreaker(breaker).Send(); hc.Err != nil {
+			t.Fatalf("半开试探失败：%v", hc.Err)
+		}
+		if state := breaker.State(target); state != CircuitStateClosed || hits.Load() != 1 {
+			t.Errorf("状态错误：%s %d", state, hits.Load())
+		}
+		if err := <-waiting; err != nil {
+			t.Errorf("等待限流的请求失败：%v", err)
+		}
+	})
+
+	t.Run("限流：熔断拒绝的请求归还令牌", func(t *testing.T) {
+		var (
+			failing = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) }))
+			breaker = CircuitBreakerApp.New().SetMinRequests(1).SetCooldown(time.Minute)
+			limiter = RateLimiterApp.New(0.001, 2).SetFailFast(true)
+		)
+		defer failing.Close()
+
+		NewGet(failing.URL).SetCircuitBreaker(breaker).SetRateLimiter(limiter).Send() // 打开熔断器
+
+		for range 3 {
+			if hc := NewGet(failing.URL).SetCircuitBreaker(breaker).SetRateLimiter(limiter).Send(); !errors.Is(hc.Err, &CircuitOpenErr) {
+				t.Fatalf("期望熔断错误，实际：%v", hc.Err)
+			}
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	// RetryPolicy 重试策略
+	RetryPolicy struct {
+		attempts          int
+		sleep             time.Duration
+		maxSleep          time.Duration
+		statusCodes       map[int]struct{}
+		methods           map[string]struct{}
+		respectRetryAfter bool
+		maxRetryAfter     time.Duration
+	}
+
+	// RetryAttempt 单次请求记录
+	RetryAttempt struct {
+		Attempt    int
+		StatusCode int
+		Err        error
+		StartAt    time.Time
+		Duration   time.Duration
+		RetryAfter time.Duration
+	}
+)
+
+var RetryPolicyApp RetryPolicy
+
+// New 实例化：重试策略（默认仅重试幂等方法及429、500、502、503、504状态码，最大重试间隔30秒）
+func (*RetryPolicy) New(attempts int) *RetryPolicy {
+	return (&RetryPolicy{
+		attempts:          attempts,
+		sleep:             500 * time.Millisecond,
+		maxSleep:          30 * time.Second,
+		respectRetryAfter: true,
+		maxRetryAfter:     time.Minute,
+	}).
+		SetStatusCodes(http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout).
+		SetMethods(http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace)
+}
+
+// SetSleep 设置首次重试间隔（之后指数退避）
+func (my *RetryPolicy) SetSleep(sleep time.Duration) *RetryPolicy {
+	my.sleep = sleep
+
+	return my
+}
+
+// SetMaxSleep 设置最大重试间隔：指数退避不会超过该值（不含随机抖动），0表示不限制
+func (my *RetryPolicy) SetMaxSleep(maxSleep time.Duration) *RetryPolicy {
+	my.maxSleep = maxSleep
+
+	return my
+}
+
+// SetStatusCodes 设置需要重试的状态码
+func (my *RetryPolicy) SetStatusCodes(statusCodes ...int) *RetryPolicy {
+	my.statusCodes = make(map[int]struct{}, len(statusCodes))
+	for _, statusCode := range statusCodes {
+		my.statusCodes[statusCode] = struct{}{}
+	}
+
+	return my
+}
+
+// SetMethods 设置允许重试的请求方法（非幂等方法需显式加入）
+func (my *RetryPolicy) SetMethods(methods ...string) *RetryPolicy {
+	my.methods = make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		my.methods[method] = struct{}{}
+	}
+
+	return my
+}
+
+// SetRespectRetryAfter 设置是否遵循响应头Retry-After
+func (my *RetryPolicy) SetRespectRetryAfter(respectRetryAfter bool) *RetryPolicy {
+	my.respectRetryAfter = respectRetryAfter
+
+	return my
+}
+
+// SetMaxRetryAfter 设置Retry-After最大等待时间
+func (my *RetryPolicy) SetMaxRetryAfter(maxRetryAfter time.Duration) *RetryPolicy {
+	my.maxRetryAfter = maxRetryAfter
+
+	return my
+}
+
+// allowMethod 检查请求方法是否允许重试
+func (my *RetryPolicy) allowMethod(method string) bool {
+	_, ok := my.methods[method]
+
+	return ok
+}
+
+// shouldRetry 检查是否需要重试
+func (my *RetryPolicy) shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, &CircuitOpenErr) && !errors.Is(err, &RateLimitErr)
+	}
+
+	_, ok := my.statusCodes[res.StatusCode]
+
+	return ok
+}
+
+// retryAfter 解析响应头Retry-After
+func (my *RetryPolicy) retryAfter(res *http.Response) time.Duration {
+	if !my.respectRetryAfter || res == nil {
+		return 0
+	}
+
+	var (
+		value = res.Header.Get("Retry-After")
+		wait  time.Duration
+	)
+
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
+	}
+
+	if wait < 0 {
+		return 0
+	}
+	if my.maxRetryAfter > 0 && wait > my.maxRetryAfter {
+		return my.maxRetryAfter
+	}
+
+	return wait
+}
+
+// SetRetryPolicy 设置重试策略
+func (my *HttpClient) SetRetryPolicy(retryPolicy *RetryPolicy) *HttpClient {
+	my.retryPolicy = retryPolicy
+
+	return my
+}
+
+// GetRetryHistory 获取最近一次发送的请求记录
+func (my *HttpClient) GetRetryHistory() []RetryAttempt { return my.retryHistory }
+
+// cloneRequest 复制请求对象并重建请求体
+func (my *HttpClient) cloneRequest() (*http.Request, error) {
+	req := my.request.Clone(my.getContext())
+	if my.request.GetBody != nil {
+		body, err := my.request.GetBody()
+		if err != nil {
+			return nil, GenerateRequestErr.Wrap(err)
+		}
+		req.Body = body
+	}
+
+	return req, nil
+}
+
+// attempt 执行单次请求并记录
+func (my *HttpClient) attempt(terminal InterceptorNext) (res *http.Response, err error) {
+	req, err := my.cloneRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	// 先获取限流令牌再经过熔断器：被限流的请求不会占用半开试探名额
+	startAt := time.Now()
+	if err = my.limitRate(req); err == nil {
+		if res, err = my.breakCircuit(req, func(req *http.Request) (*http.Response, error) { return my.traceRequest(req, terminal) }); errors.Is(err, &CircuitOpenErr) {
+			my.refundRate(req)
+		}
+	}
+
+	record := RetryAttempt{Attempt: len(my.retryHistory) + 1, Err: err, StartAt: startAt, Duration: time.Since(startAt)}
+	if res != nil {
+		record.StatusCode = res.StatusCode
+	}
+	my.retryHistory = append(my.retryHistory, record)
+
+	return res, err
+}
+
+// backoff 获取第n次重试前的等待时间：按策略指数退避并截断到最大间隔，再增加不超过其一半的随机抖动，不受Retry-After影响
+func (my *RetryPolicy) backoff(n int) time.Duration {
+	sleep := my.sleep
+	for i := 1; i < n && (my.maxSleep <= 0 || sleep < my.maxSleep) && sleep < math.MaxInt64/4; i++ { // 不超过四分之一最大值，加上随机抖动后不会溢出
+		sleep *= 2
+	}
+	if my.maxSleep > 0 && sleep > my.maxSleep {
+		sleep = my.maxSleep
+	}
+
+	if half := int64(sleep / 2); half > 0 {
+		sleep += time.Duration(rand.Int63n(half))
+	}
+
+	return sleep
+}
+
+// doWithRetry 按重试策略执行请求
+func (my *HttpClient) doWithRetry(terminal InterceptorNext) (*http.Response, error) {
+	my.retryHistory = nil
+
+	policy := my.retryPolicy
+	if policy == nil || policy.attempts <= 1 || !policy.allowMethod(my.request.Method) || (my.request.Body != nil && my.request.Body != http.NoBody && my.request.GetBody == nil) {
+		return my.attempt(terminal)
+	}
+
+	ctx := my.getContext()
+	for {
+		res, err := my.attempt(terminal)
+		if !policy.shouldRetry(ctx, res, err) || len(my.retryHistory) >= policy.attempts {
+			return res, err
+		}
+
+		// Retry-After仅决定本次等待时间，之后仍按策略退避
+		wait := policy.backoff(len(my.retryHistory))
+		if res != nil {
+			if retryAfter := policy.retryAfter(res); retryAfter > 0 {
+				wait = retryAfter
+				my.retryHistory[len(my.retryHistory)-1].RetryAfter = retryAfter
+			}
+
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package httpClient
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// HttpClientSession http会话：多个请求共享cookie、默认请求头与基础地址
+	HttpClientSession struct {
+		jar     *sessionJar
+		baseUrl string
+		headers map[string][]string
+		timeout time.Duration
+		mu      sync.RWMutex
+	}
+
+	// SessionCookie 持久化的cookie：记录设置cookie时的请求地址，重新加载时按原地址写回
+	SessionCookie struct {
+		Url    string       `json:"url"`
+		Cookie *http.Cookie `json:"cookie"`
+	}
+
+	// sessionJar 可持久化的cookie容器：在标准cookiejar基础上记录全部设置过的cookie
+	sessionJar struct {
+		jar     *cookiejar.Jar
+		cookies map[string]SessionCookie
+		mu      sync.Mutex
+	}
+)
+
+var HttpClientSessionApp HttpClientSession
+
+// New 实例化：http会话
+func (*HttpClientSession) New() *HttpClientSession {
+	return &HttpClientSession{jar: newSessionJar(), headers: map[string][]string{}}
+}
+
+// SetBaseUrl 设置基础地址：请求地址为相对地址时拼接在基础地址之后
+func (my *HttpClientSession) SetBaseUrl(baseUrl string) *HttpClientSession {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.baseUrl = baseUrl
+
+	return my
+}
+
+// SetHeaders 设置默认请求头
+func (my *HttpClientSession) SetHeaders(headers map[string][]string) *HttpClientSession {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.headers = make(map[string][]string, len(headers))
+	for k, v := range headers {
+		my.headers[k] = slices.Clone(v)
+	}
+
+	return my
+}
+
+// AddHeaders 追加默认请求头
+func (my *HttpClientSession) AddHeaders(headers map[string][]string) *HttpClientSession {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	for k, v := range headers {
+		my.headers[k] = append(my.headers[k], v...)
+	}
+
+	return my
+}
+
+// SetTimeout 设置默认超时
+func (my *HttpClientSession) SetTimeout(timeout time.Duration) *HttpClientSession {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.timeout = timeout
+
+	return my
+}
+
+// Request 创建使用会话的请求：请求自身设置的请求头替换同名的会话默认请求头
+func (my *HttpClientSession) Request(method, url string) *HttpClient {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	hc := NewHttpClient(my.resolveUrl(url)).SetMethod(method).SetCookieJar(my.jar)
+	hc.defaultHeaders = make(map[string][]string, len(my.headers))
+	for k, v := range my.headers {
+		hc.defaultHeaders[k] = slices.Clone(v)
+	}
+	if my.timeout > 0 {
+		hc.SetTimeout(my.timeout)
+	}
+
+	return hc
+}
+
+// Get 创建GET请求
+func (my *HttpClientSession) Get(url string) *HttpClient { return my.Request(http.MethodGet, url) }
+
+// Post 创建POST请求
+func (my *HttpClientSession) Post(url string) *HttpClient { return my.Request(http.MethodPost, url) }
+
+// Put 创建PUT请求
+func (my *HttpClientSession) Put(url string) *HttpClient { return my.Request(http.MethodPut, url) }
+
+// Delete 创建DELETE请求
+func (my *HttpClientSession) Delete(url string) *HttpClient {
+	return my.Request(http.MethodDelete, url)
+}
+
+// resolveUrl 拼接基础地址：绝对地址保持不变
+func (my *HttpClientSession) resolveUrl(rawUrl string) string {
+	if my.baseUrl == "" {
+		return rawUrl
+	}
+
+	if u, err := url.Parse(rawUrl); err == nil && u.IsAbs() {
+		return rawUrl
+	}
+
+	if rawUrl == "" {
+		return my.baseUrl
+	}
+
+	return strings.TrimRight(my.baseUrl, "/") + "/" + strings.TrimLeft(rawUrl, "/")
+}
+
+// GetCookieJar 获取会话cookie容器
+func (my *HttpClientSession) GetCookieJar() http.CookieJar { return my.jar }
+
+// GetCookies 获取指定地址可用的cookie
+func (my *HttpClientSession) GetCookies(rawUrl string) []*http.Cookie {
+	u, err := url.Parse(my.resolveUrl(rawUrl))
+	if err != nil {
+		return nil
+	}
+
+	return my.jar.Cookies(u)
+}
+
+// SetCookies 手动设置cookie
+func (my *HttpClientSession) SetCookies(rawUrl string, cookies []*http.Cookie) *HttpClientSession {
+	if u, err := url.Parse(my.resolveUrl(rawUrl)); err == nil {
+		my.jar.SetCookies(u, cookies)
+	}
+
+	return my
+}
+
+// CleanCookies 清空cookie
+func (my *HttpClientSession) CleanCookies() *HttpClientSession {
+	my.jar.clean()
+
+	return my
+}
+
+// Save 将cookie保存到文件（json格式），已过期的cookie不保存
+func (my *HttpClientSession) Save(filename string) error {
+	content, err := json.MarshalIndent(my.jar.all(), "", "  ")
+	if err != nil {
+		return SessionErr.Wrap(err)
+	}
+
+	if err = os.WriteFile(filename, content, 0600); err != nil {
+		return SessionErr.Wrap(err)
+	}
+
+	return nil
+}
+
+// Load 从文件读取cookie：替换当前会话中的全部cookie
+func (my *HttpClientSession) Load(filename string) error {
+	var cookies []SessionCookie
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return SessionErr.Wrap(err)
+	}
+
+	if err = json.Unmarshal(content, &cookies); err != nil {
+		return SessionErr.Wrap(err)
+	}
+
+	my.jar.clean()
+	for _, cookie := range cookies {
+		u, err := url.Parse(cookie.Url)
+		if err != nil || cookie.Cookie == nil {
+			continue
+		}
+		my.jar.SetCookies(u, []*http.Cookie{cookie.Cookie})
+	}
+
+	return nil
+}
+
+// SetCookieJar 设置cookie容器
+func (my *HttpClient) SetCookieJar(jar http.CookieJar) *HttpClient {
+	my.cookieJar = jar
+
+	return my
+}
+
+// newSessionJar 实例化：可持久化的cookie容器
+func newSessionJar() *sessionJar {
+	jar, _ := cookiejar.New(nil)
+
+	return &sessionJar{jar: jar, cookies: map[string]SessionCookie{}}
+}
+
+// SetCookies 实现http.CookieJar
+func (my *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.jar.SetCookies(u, cookies)
+
+	for _, cookie := range cookies {
+		var (
+			domain = strings.TrimPrefix(cookie.Domain, ".")
+			path   = cookie.Path
+		)
+		if domain == "" {
+			domain = u.Hostname()
+		}
+		if path == "" {
+			path = "/"
+		}
+
+		key := domain + ";" + path + ";" + cookie.Name
+		if cookie.MaxAge < 0 || (!cookie.Expires.IsZero() && cookie.Expires.Before(time.Now())) {
+			delete(my.cookies, key) // 服务端删除cookie
+			continue
+		}
+
+		saved := *cookie
+		if saved.MaxAge > 0 {
+			// MaxAge为相对时间，转换为绝对过期时间后持久化
+			saved.Expires, saved.MaxAge = time.Now().Add(time.Duration(saved.MaxAge)*time.Second), 0
+		}
+		my.cookies[key] = SessionCookie{Url: (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String(), Cookie: &saved}
+	}
+}
+
+// Cookies 实现http.CookieJar
+func (my *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	return my.jar.Cookies(u)
+}
+
+// all 获取全部未过期的cookie
+func (my *sessionJar) all() []SessionCookie {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	var (
+		now     = time.Now()
+		cookies = make([]SessionCookie, 0, len(my.cookies))
+	)
+	for _, key := range slices.Sorted(maps.Keys(my.cookies)) {
+		cookie := my.cookies[key]
+		if !cookie.Cookie.Expires.IsZero() && cookie.Cookie.Expires.Before(now) {
+			continue
+		}
+		cookies = append(cookies, cookie)
+	}
+
+	return cookies
+}
+
+// clean 清空cookie
+func (my *sessionJar) clean() {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.jar, _ = cookiejar.New(nil)
+	my.cookies = map[string]SessionCookie{}
+}
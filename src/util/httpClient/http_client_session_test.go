@@ -0,0 +1,86 @@
+package httpClient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test11Session(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc", Path: "/", MaxAge: 3600})
+		case "/api/headers":
+			_, _ = w.Write([]byte(strings.Join(r.Header.Values("X-App"), ",")))
+		case "/api/accept":
+			_, _ = w.Write([]byte(strings.Join(r.Header.Values("Accept"), ",") + "|" + strings.Join(r.Header.Values("Content-Type"), ",")))
+		case "/api/profile":
+			cookie, err := r.Cookie("sid")
+			if err != nil || cookie.Value != "abc" || r.Header.Get("X-App") != "nova" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "cookies.json")
+
+	t.Run("会话：共享cookie与默认请求头", func(t *testing.T) {
+		session := HttpClientSessionApp.New().SetBaseUrl(server.URL + "/api/").SetHeaders(map[string][]string{"X-App": {"nova"}})
+
+		if hc := session.Get("/profile").Send(); hc.Err != nil || hc.GetResponse().StatusCode != http.StatusUnauthorized {
+			t.Fatalf("未登录时期望401：%v", hc.Err)
+		}
+		if hc := session.Post("login").Send(); hc.Err != nil {
+			t.Fatalf("登录失败：%v", hc.Err)
+		}
+		if hc := session.Get("profile").Send(); hc.Err != nil || hc.GetResponse().StatusCode != http.StatusOK {
+			t.Fatalf("登录后期望200：%v", hc.Err)
+		}
+
+		if err := session.Save(filename); err != nil {
+			t.Fatalf("保存失败：%v", err)
+		}
+	})
+
+	t.Run("会话：请求头替换默认请求头", func(t *testing.T) {
+		session := HttpClientSessionApp.New().SetBaseUrl(server.URL + "/api").SetHeaders(map[string][]string{"X-App": {"nova"}})
+
+		if hc := session.Get("headers").Send(); string(hc.GetResponseRawBody()) != "nova" {
+			t.Errorf("默认请求头错误：%s", hc.GetResponseRawBody())
+		}
+		if hc := session.Get("headers").AddHeaders(map[string][]string{"x-app": {"custom"}}).Send(); string(hc.GetResponseRawBody()) != "custom" {
+			t.Errorf("请求头未替换默认请求头：%s", hc.GetResponseRawBody())
+		}
+
+		session.SetHeaders(map[string][]string{"Accept": {"application/json"}, "Content-Type": {"text/plain"}})
+		if hc := session.Post("accept").Send(); string(hc.GetResponseRawBody()) != "application/json|text/plain" {
+			t.Errorf("默认Accept、Content-Type未生效：%s", hc.GetResponseRawBody())
+		}
+		if hc := session.Post("accept").SetJsonBody(map[string]string{}).Send(); string(hc.GetResponseRawBody()) != "application/json|application/json" {
+			t.Errorf("请求体类型未替换默认Content-Type：%s", hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("会话：从文件恢复cookie", func(t *testing.T) {
+		session := HttpClientSessionApp.New().SetBaseUrl(server.URL).AddHeaders(map[string][]string{"X-App": {"nova"}})
+		if err := session.Load(filename); err != nil {
+			t.Fatalf("读取失败：%v", err)
+		}
+
+		if cookies := session.GetCookies("/api"); len(cookies) != 1 || cookies[0].Value != "abc" {
+			t.Errorf("cookie恢复错误：%v", cookies)
+		}
+		if hc := session.Get("/api/profile").Send(); hc.Err != nil || hc.GetResponse().StatusCode != http.StatusOK {
+			t.Errorf("恢复后期望200：%v", hc.Err)
+		}
+
+		if session.CleanCookies(); len(session.GetCookies("/api")) != 0 {
+			t.Errorf("cookie未清空")
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package httpClient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// HttpClientStream http客户端流式响应：不缓存响应体
+	HttpClientStream struct {
+		httpClient *HttpClient
+	}
+
+	// SseEvent 服务端推送事件（text/event-stream）
+	SseEvent struct {
+		Id    string
+		Event string
+		Data  string
+		Retry int
+	}
+)
+
+var HttpClientStreamApp HttpClientStream
+
+// New 实例化：http客户端流式响应
+func (*HttpClientStream) New(httpClient *HttpClient) *HttpClientStream {
+	return &HttpClientStream{httpClient: httpClient}
+}
+
+// Stream 使用流式响应
+func (my *HttpClient) Stream() *HttpClientStream { return HttpClientStreamApp.New(my) }
+
+// Open 发送请求并返回响应流：调用方负责关闭，客户端超时仅作用于等待响应头的阶段，读取响应流不受超时限制，需通过上下文取消
+func (my *HttpClientStream) Open() (io.ReadCloser, error) {
+	defer func() { my.httpClient.isReady = false }()
+
+	client := my.httpClient.beforeSend()
+	if my.httpClient.Err != nil {
+		return nil, my.httpClient.Err
+	}
+	timeout := client.Timeout
+	client.Timeout = 0 // 超时包含读取响应体的时间，会中断长连接的流式响应
+
+	var (
+		ctx, cancel = context.WithCancel(my.httpClient.getContext())
+		timer       *time.Timer
+		timedOut    atomic.Bool
+	)
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			cancel()
+		})
+	}
+
+	my.httpClient.withContext(ctx, func() {
+		my.httpClient.response, my.httpClient.Err = my.httpClient.do(client)
+	})
+
+	// 收到响应头后停止计时，计时已结束时按超时处理
+	if timer != nil && !timer.Stop() && my.httpClient.Err == nil {
+		_ = my.httpClient.response.Body.Close()
+		my.httpClient.Err = context.DeadlineExceeded
+	}
+	if my.httpClient.Err != nil {
+		cancel()
+		if timedOut.Load() {
+			my.httpClient.Err = RequestTimeoutErr.Wrap(context.DeadlineExceeded)
+		}
+		return nil, my.httpClient.Err
+	}
+
+	my.httpClient.response.Body = &streamBody{ReadCloser: my.httpClient.response.Body, cancel: cancel}
+
+	return my.httpClient.response.Body, nil
+}
+
+// streamBody 响应流：关闭时释放上下文
+type streamBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close 关闭响应流
+func (my *streamBody) Close() error {
+	defer my.cancel()
+
+	return my.ReadCloser.Close()
+}
+
+// EachLine 逐行读取响应流（忽略空行），回调返回StreamStopErr时正常结束
+func (my *HttpClientStream) EachLine(fn func(line []byte) error) error {
+	body, err := my.Open()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimRight(line, "\r\n"); len(line) > 0 {
+			if e := fn(line); e != nil {
+				return my.stop(e)
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return my.stop(my.httpClient.wrapReadErr(err))
+		}
+	}
+}
+
+// EachSse 逐个读取服务端推送事件，回调返回StreamStopErr时正常结束
+func (my *HttpClientStream) EachSse(fn func(event SseEvent) error) error {
+	var (
+		event SseEvent
+		data  []string
+	)
+
+	if len(my.httpClient.requestHeaders["Accept"]) == 0 {
+		my.httpClient.SetHeaderAccept(AcceptEventStream)
+	}
+
+	body, err := my.Open()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return my.stop(my.httpClient.wrapReadErr(err))
+		}
+		if err != nil && line == "" {
+			return nil // 流结束时未以空行结尾的事件不派发
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if e := fn(event); e != nil {
+					return my.stop(e)
+				}
+			}
+			event, data = SseEvent{Id: event.Id}, nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue // 注释
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "event":
+			event.Event = value
+		case "id":
+			event.Id = value
+		case "retry":
+			if retry, e := strconv.Atoi(value); e == nil {
+				event.Retry = retry
+			}
+		}
+	}
+}
+
+// stop 处理回调主动停止
+func (my *HttpClientStream) stop(err error) error {
+	if errors.Is(err, &StreamStopErr) {
+		return nil
+	}
+
+	return err
+}
+
+// EachNdjson 逐行解析NDJSON响应流
+func EachNdjson[T any](stream *HttpClientStream, fn func(item T) error) error {
+	if len(stream.httpClient.requestHeaders["Accept"]) == 0 {
+		stream.httpClient.SetHeaderAccept(AcceptNdjson)
+	}
+
+	return stream.EachLine(func(line []byte) error {
+		var item T
+		if err := json.Unmarshal(line, &item); err != nil {
+			return UnmarshalJsonErr.Wrap(err)
+		}
+
+		return fn(item)
+	})
+}
+
+// EachSseJson 逐个读取服务端推送事件并将data解析为json
+func EachSseJson[T any](stream *HttpClientStream, fn func(event SseEvent, data T) error) error {
+	return stream.EachSse(func(event SseEvent) error {
+		var data T
+		if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+			return UnmarshalJsonErr.Wrap(err)
+		}
+
+		return fn(event, data)
+	})
+}
@@ -0,0 +1,607 @@
+package httpClient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo-Token", r.Header.Get("X-Token"))
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.RequestURI()))
+	}))
+}
+
+func Test1Interceptor(t *testing.T) {
+	server := newEchoServer()
+	defer server.Close()
+
+	t.Run("拦截器：修改请求与响应", func(t *testing.T) {
+		var orders []string
+
+		AppendGlobalInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+			orders = append(orders, "global")
+			return next(req)
+		})
+		defer CleanGlobalInterceptors()
+
+		hc := NewGet(server.URL).
+			AppendInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+				orders = append(orders, "client")
+				req.Header.Set("X-Token", "abc")
+				res, err := next(req)
+				if err != nil {
+					return nil, err
+				}
+				res.Header.Set("X-Intercepted", "yes")
+				return res, nil
+			}).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+
+		if hc.GetResponse().Header.Get("X-Echo-Token") != "abc" {
+			t.Errorf("请求头未被拦截器修改")
+		}
+		if hc.GetResponse().Header.Get("X-Intercepted") != "yes" {
+			t.Errorf("响应头未被拦截器修改")
+		}
+		if len(orders) != 2 || orders[0] != "global" || orders[1] != "client" {
+			t.Errorf("拦截器执行顺序错误：%v", orders)
+		}
+	})
+
+	t.Run("拦截器：短路请求", func(t *testing.T) {
+		hc := NewGet(server.URL).
+			AppendInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusTeapot,
+					Header:     http.Header{},
+					Body:       io.NopCloser(bytes.NewReader([]byte("short"))),
+					Request:    req,
+				}, nil
+			}).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+		if hc.GetResponse().StatusCode != http.StatusTeapot || string(hc.GetResponseRawBody()) != "short" {
+			t.Errorf("短路响应错误：%d %s", hc.GetResponse().StatusCode, hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("拦截器：未返回响应", func(t *testing.T) {
+		hc := NewGet(server.URL).
+			AppendInterceptors(func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) { return nil, nil }).
+			Send()
+		if !errors.Is(hc.Err, &InterceptErr) {
+			t.Errorf("期望拦截错误，实际：%v", hc.Err)
+		}
+	})
+}
+
+func Test2Retry(t *testing.T) {
+	var times int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times++
+		body, _ := io.ReadAll(r.Body)
+		if times < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	t.Run("重试：重放请求体", func(t *testing.T) {
+		times = 0
+		hc := NewPut(server.URL).
+			SetPlainBody("hello").
+			SetRetryPolicy(RetryPolicyApp.New(3).SetSleep(time.Millisecond)).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+		if string(hc.GetResponseRawBody()) != "hello" {
+			t.Errorf("请求体未重放：%s", hc.GetResponseRawBody())
+		}
+		if history := hc.GetRetryHistory(); len(history) != 3 || history[0].StatusCode != http.StatusServiceUnavailable || history[2].StatusCode != http.StatusOK {
+			t.Errorf("请求记录错误：%+v", history)
+		}
+	})
+
+	t.Run("重试：非幂等方法不重试", func(t *testing.T) {
+		times = 0
+		hc := NewPost(server.URL).
+			SetRetryPolicy(RetryPolicyApp.New(3).SetSleep(time.Millisecond)).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+		if hc.GetResponse().StatusCode != http.StatusServiceUnavailable || len(hc.GetRetryHistory()) != 1 {
+			t.Errorf("非幂等方法不应重试：%d %d", hc.GetResponse().StatusCode, len(hc.GetRetryHistory()))
+		}
+	})
+
+	t.Run("重试：次数耗尽返回最后一次响应", func(t *testing.T) {
+		times = -10
+		hc := NewGet(server.URL).
+			SetRetryPolicy(RetryPolicyApp.New(2).SetSleep(time.Millisecond)).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+		if hc.GetResponse().StatusCode != http.StatusServiceUnavailable || len(hc.GetRetryHistory()) != 2 {
+			t.Errorf("重试次数错误：%d %d", hc.GetResponse().StatusCode, len(hc.GetRetryHistory()))
+		}
+	})
+
+	t.Run("重试：退避间隔有上限并带随机抖动", func(t *testing.T) {
+		for _, c := range []struct {
+			policy   *RetryPolicy
+			n        int
+			min, max time.Duration
+		}{
+			{RetryPolicyApp.New(3).SetSleep(10 * time.Millisecond), 1, 10 * time.Millisecond, 15 * time.Millisecond},
+			{RetryPolicyApp.New(3).SetSleep(10 * time.Millisecond), 3, 40 * time.Millisecond, 60 * time.Millisecond},
+			{RetryPolicyApp.New(100), 100, 30 * time.Second, 45 * time.Second},
+			{RetryPolicyApp.New(100).SetMaxSleep(0), 100, time.Duration(math.MaxInt64 / 4), time.Duration(math.MaxInt64)},
+		} {
+			if wait := c.policy.backoff(c.n); wait < c.min || wait >= c.max {
+				t.Errorf("第%d次重试间隔错误：%s", c.n, wait)
+			}
+		}
+	})
+
+	for name, retryAfter := range map[string]func() string{
+		"秒数":     func() string { return "1" },
+		"HTTP日期": func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) },
+	} {
+		t.Run("重试：Retry-After"+name, func(t *testing.T) {
+			var count int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if count++; count == 1 {
+					w.Header().Set("Retry-After", retryAfter())
+				}
+				if count < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+
+			hc := NewGet(server.URL).SetRetryPolicy(RetryPolicyApp.New(3).SetSleep(10 * time.Millisecond)).Send()
+			if hc.Err != nil {
+				t.Fatalf("发送失败：%v", hc.Err)
+			}
+
+			history := hc.GetRetryHistory()
+			if len(history) != 3 || history[2].StatusCode != http.StatusOK {
+				t.Fatalf("请求记录错误：%+v", history)
+			}
+			if history[0].RetryAfter <= 0 || history[0].RetryAfter > 2*time.Second {
+				t.Errorf("Retry-After解析错误：%s", history[0].RetryAfter)
+			}
+			if waited := history[1].StartAt.Sub(history[0].StartAt); waited < history[0].RetryAfter {
+				t.Errorf("未等待Retry-After：%s", waited)
+			}
+			// 第二次重试按策略退避（20ms），而不是在Retry-After基础上翻倍
+			if waited := history[2].StartAt.Sub(history[1].StartAt.Add(history[1].Duration)); waited > 500*time.Millisecond {
+				t.Errorf("退避时间错误：%s", waited)
+			}
+		})
+	}
+}
+
+func Test3Context(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	t.Run("上下文：取消请求", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		if hc := NewGet(server.URL).SendWithContext(ctx); !errors.Is(hc.Err, &RequestCanceledErr) {
+			t.Errorf("期望取消错误，实际：%v", hc.Err)
+		}
+	})
+
+	t.Run("上下文：仅作用于本次发送", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		hc := NewGet(server.URL)
+		if hc.SendWithContext(ctx); !errors.Is(hc.Err, &RequestCanceledErr) {
+			t.Errorf("期望取消错误，实际：%v", hc.Err)
+		}
+		if hc.Send(); hc.Err != nil || hc.GetContext().Err() != nil {
+			t.Errorf("后续发送不应继承已取消的上下文：%v", hc.Err)
+		}
+	})
+
+	t.Run("上下文：毫秒级超时", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetTimeout(50 * time.Millisecond).Send(); !errors.Is(hc.Err, &RequestTimeoutErr) {
+			t.Errorf("期望超时错误，实际：%v", hc.Err)
+		}
+	})
+}
+
+func Test4TransportPool(t *testing.T) {
+	server := newEchoServer()
+	defer server.Close()
+
+	t.Run("传输层连接池：按名称复用", func(t *testing.T) {
+		if _, err := TransportPoolApp.Once().Set("test", &TransportConfig{MaxIdleConnsPerHost: 4, DialTimeout: time.Second}); err != nil {
+			t.Fatalf("设置传输层失败：%v", err)
+		}
+		defer TransportPoolApp.Once().Remove("test")
+
+		for i := range 3 {
+			hc := NewGet(server.URL).SetTransportName("test").Send()
+			if hc.Err != nil {
+				t.Fatalf("发送失败：%v", hc.Err)
+			}
+			if i > 0 && !hc.GetTiming().ConnReused {
+				t.Errorf("第%d次请求未复用连接", i+1)
+			}
+
+			transport, err := hc.getTransport()
+			if err != nil || transport != TransportPoolApp.Once().Get("test") {
+				t.Errorf("未使用连接池中的传输层：%v", err)
+			}
+		}
+	})
+
+	t.Run("传输层连接池：完整TLS配置不进入连接池", func(t *testing.T) {
+		pool := TransportPoolApp.Once()
+		pool.tlsMu.Lock()
+		count := len(pool.tlsKeys)
+		pool.tlsMu.Unlock()
+
+		hc := NewGet(server.URL).SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+		first, err := hc.getTransport()
+		if err != nil {
+			t.Fatalf("获取传输层失败：%v", err)
+		}
+		if second, _ := hc.getTransport(); second != first {
+			t.Errorf("同一客户端应复用传输层")
+		}
+		if other, _ := NewGet(server.URL).SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}).getTransport(); other == first {
+			t.Errorf("不同TLS配置不应复用传输层")
+		}
+		if changed, _ := hc.SetTLSConfig(&tls.Config{}).getTransport(); changed == first {
+			t.Errorf("修改TLS配置后应重新生成传输层")
+		}
+
+		pool.tlsMu.Lock()
+		defer pool.tlsMu.Unlock()
+		if len(pool.tlsKeys) != count {
+			t.Errorf("完整TLS配置的传输层不应进入连接池：%d -> %d", count, len(pool.tlsKeys))
+		}
+	})
+
+	t.Run("传输层连接池：TLS传输层数量有上限", func(t *testing.T) {
+		for i := range maxTLSTransports + 10 {
+			if _, err := NewGet(server.URL).SetMinTLSVersion(uint16(i + 1)).getTransport(); err != nil {
+				t.Fatalf("获取传输层失败：%v", err)
+			}
+		}
+
+		pool := TransportPoolApp.Once()
+		pool.tlsMu.Lock()
+		defer pool.tlsMu.Unlock()
+		if len(pool.tlsKeys) != maxTLSTransports || pool.tlsTransports.Len() != maxTLSTransports {
+			t.Errorf("TLS传输层数量错误：%d", len(pool.tlsKeys))
+		}
+	})
+
+	t.Run("传输层连接池：名称不存在", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetTransportName("not-exist").Send(); !errors.Is(hc.Err, &TransportNotFoundErr) {
+			t.Errorf("期望传输层不存在错误，实际：%v", hc.Err)
+		}
+	})
+}
+
+func Test6Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		switch r.URL.Path {
+		case "/ndjson":
+			for i := range 3 {
+				_, _ = fmt.Fprintf(w, "{\"id\":%d}\n", i)
+				flusher.Flush()
+			}
+		case "/slow":
+			for i := range 3 {
+				_, _ = fmt.Fprintf(w, "{\"id\":%d}\n", i)
+				flusher.Flush()
+				time.Sleep(50 * time.Millisecond)
+			}
+		case "/hang":
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		case "/sse":
+			_, _ = w.Write([]byte(": comment\nevent: tick\nid: 1\ndata: {\"id\":1}\n\ndata: line1\ndata: line2\n\ndata: incomplete"))
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	t.Run("流式响应：NDJSON", func(t *testing.T) {
+		var ids []int
+		err := EachNdjson(NewGet(server.URL+"/ndjson").Stream(), func(item struct {
+			Id int `json:"id"`
+		}) error {
+			ids = append(ids, item.Id)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("读取失败：%v", err)
+		}
+		if len(ids) != 3 || ids[2] != 2 {
+			t.Errorf("读取结果错误：%v", ids)
+		}
+	})
+
+	t.Run("流式响应：提前停止", func(t *testing.T) {
+		var times int
+		err := NewGet(server.URL + "/ndjson").Stream().EachLine(func(line []byte) error {
+			times++
+			return StreamStopErr.New("")
+		})
+		if err != nil || times != 1 {
+			t.Errorf("提前停止错误：%v %d", err, times)
+		}
+	})
+
+	t.Run("流式响应：等待响应头超时", func(t *testing.T) {
+		startAt := time.Now()
+		if _, err := NewGet(server.URL + "/hang").SetTimeout(50 * time.Millisecond).Stream().Open(); !errors.Is(err, &RequestTimeoutErr) {
+			t.Errorf("期望超时错误，实际：%v", err)
+		}
+		if elapsed := time.Since(startAt); elapsed > 500*time.Millisecond {
+			t.Errorf("超时未生效：%s", elapsed)
+		}
+	})
+
+	t.Run("流式响应：不受客户端超时限制", func(t *testing.T) {
+		var times int
+		err := NewGet(server.URL + "/slow").SetTimeout(60 * time.Millisecond).Stream().EachLine(func(line []byte) error {
+			times++
+			return nil
+		})
+		if err != nil || times != 3 {
+			t.Errorf("读取失败：%v %d", err, times)
+		}
+	})
+
+	t.Run("流式响应：SSE", func(t *testing.T) {
+		var events []SseEvent
+		err := NewGet(server.URL + "/sse").Stream().EachSse(func(event SseEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("读取失败：%v", err)
+		}
+		if len(events) != 2 || events[0].Event != "tick" || events[0].Id != "1" || events[1].Data != "line1\nline2" || events[1].Id != "1" {
+			t.Errorf("读取结果错误：%+v", events)
+		}
+	})
+}
+
+func Test7Multiple(t *testing.T) {
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxFlight = max(maxFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			time.Sleep(20 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("批量请求：并发限制与结果", func(t *testing.T) {
+		multiple := MultipleApp.New().SetConcurrency(2)
+		for range 5 {
+			multiple.Append(NewGet(server.URL))
+		}
+		bad := NewGet(server.URL + "/bad")
+		multiple.Append(bad)
+
+		result := multiple.Send().GetResult()
+		if result.HasError() {
+			t.Fatalf("批量请求失败：%v", result.Err())
+		}
+		if maxFlight > 2 {
+			t.Errorf("并发数超过限制：%d", maxFlight)
+		}
+		if item, ok := result.Get(bad); !ok || item.StatusCode != http.StatusBadRequest || item.Index != 5 {
+			t.Errorf("结果映射错误：%+v", item)
+		}
+		if NewMultiple() == multiple || len(NewMultiple().GetClients()) != 0 {
+			t.Errorf("批量请求对象不应共享")
+		}
+	})
+
+	t.Run("批量请求：超时与快速失败", func(t *testing.T) {
+		result := MultipleApp.New().
+			Append(NewGet(server.URL + "/slow")).
+			Append(NewGet(server.URL + "/slow")).
+			SetTimeout(50 * time.Millisecond).
+			Send().
+			GetResult()
+		if len(result.Failed()) != 2 || !errors.Is(result.Failed()[0].Err, &RequestTimeoutErr) {
+			t.Errorf("期望全部超时：%v", result.Err())
+		}
+
+		result = MultipleApp.New().
+			SetConcurrency(1).
+			SetFailFast(true).
+			Append(NewGet("http://127.0.0.1:0")).
+			Append(NewGet(server.URL)).
+			Send().
+			GetResult()
+		if len(result.Failed()) != 2 || !errors.Is(result.Items()[1].Err, &RequestCanceledErr) {
+			t.Errorf("期望快速失败：%v", result.Err())
+		}
+	})
+
+	t.Run("批量请求：上下文仅作用于本次发送", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		multiple := MultipleApp.New().Append(NewGet(server.URL))
+		if result := multiple.SendWithContext(ctx).GetResult(); !errors.Is(result.Items()[0].Err, &RequestCanceledErr) {
+			t.Errorf("期望取消错误，实际：%v", result.Err())
+		}
+		if result := multiple.Send().GetResult(); result.HasError() {
+			t.Errorf("后续发送不应继承已取消的上下文：%v", result.Err())
+		}
+	})
+}
+
+func Test10Do(t *testing.T) {
+	type (
+		user struct {
+			Name string `json:"name"`
+		}
+		apiError struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.URL.Query().Get("name") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":40001,"message":"name required"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"name":%q}`, r.URL.Query().Get("name"))
+	}))
+	defer server.Close()
+
+	t.Run("泛型解析：成功响应", func(t *testing.T) {
+		u, err := Do[user, apiError](NewGet(server.URL + "?name=nova"))
+		if err != nil {
+			t.Fatalf("请求失败：%v", err)
+		}
+		if u.Name != "nova" {
+			t.Errorf("解析错误：%+v", u)
+		}
+	})
+
+	t.Run("泛型解析：错误响应", func(t *testing.T) {
+		_, err := Do[user, apiError](NewGet(server.URL))
+
+		var responseErr *ResponseError[apiError]
+		if !errors.As(err, &responseErr) || !errors.Is(err, &ResponseStatusErr) {
+			t.Fatalf("期望响应错误，实际：%v", err)
+		}
+		if responseErr.StatusCode != http.StatusBadRequest || responseErr.Body == nil || responseErr.Body.Code != 40001 {
+			t.Errorf("错误体解析错误：%+v", responseErr)
+		}
+	})
+
+	t.Run("根据Content-Type解析", func(t *testing.T) {
+		var u user
+		if hc := NewGet(server.URL + "?name=nova").Send().ParseByContentType(&u); hc.Err != nil || u.Name != "nova" {
+			t.Errorf("解析错误：%v %+v", hc.Err, u)
+		}
+	})
+}
+
+func Test18Query(t *testing.T) {
+	server := newEchoServer()
+	defer server.Close()
+
+	type (
+		Page struct {
+			Page int `query:"page"`
+			Size int `query:"size,omitempty"`
+		}
+		Filter struct {
+			Page
+			Ids     []int     `query:"id"`
+			Tags    []string  `query:"tags,comma"`
+			Keyword *string   `query:"keyword"`
+			Start   time.Time `query:"start"`
+			End     time.Time `query:"end,unix"`
+			Ignore  string    `query:"-"`
+		}
+	)
+
+	t.Run("请求参数：与url中已有参数合并", func(t *testing.T) {
+		hc := NewGet(server.URL+"/?a=1&b=2").SetQueries(map[string]string{"b": "3"}).AddQuery("c", "4", "5").Send()
+		if hc.Err != nil || string(hc.GetResponseRawBody()) != "GET /?a=1&b=3&c=4&c=5" {
+			t.Errorf("请求参数错误：%v %s", hc.Err, hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("请求参数：结构体编码", func(t *testing.T) {
+		var (
+			start = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			hc    = NewGet(server.URL).SetQueryStruct(Filter{Page: Page{Page: 1}, Ids: []int{1, 2}, Tags: []string{"a", "b"}, Start: start, End: start, Ignore: "x"}).Send()
+		)
+
+		if expect := "GET /?end=1704164645&id=1&id=2&page=1&start=2024-01-02T03%3A04%3A05Z&tags=a%2Cb"; hc.Err != nil || string(hc.GetResponseRawBody()) != expect {
+			t.Errorf("请求参数错误：%v %s", hc.Err, hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("请求参数：构造器格式选项", func(t *testing.T) {
+		builder := QueryBuilderApp.New().SetTimeFormat(time.DateOnly).SetSliceFormat(QuerySliceBrackets).
+			Struct(struct {
+				Ids  []int     `query:"id"`
+				Date time.Time `query:"date"`
+			}{Ids: []int{1, 2}, Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+
+		if query, err := builder.Encode(); err != nil || query != "date=2024-01-02&id%5B%5D=1&id%5B%5D=2" {
+			t.Errorf("编码错误：%v %s", err, query)
+		}
+
+		if hc := NewGet(server.URL).SetQueryStruct(struct{ M map[string]int }{}); !errors.Is(hc.Err, &QueryErr) {
+			t.Errorf("期望编码失败，实际：%v", hc.Err)
+		}
+	})
+}
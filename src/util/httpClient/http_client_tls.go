@@ -0,0 +1,182 @@
+package httpClient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+)
+
+// SetCertBytes 设置SSL根证书（PEM格式）
+func (my *HttpClient) SetCertBytes(cert []byte) *HttpClient {
+	my.cert = cert
+
+	return my
+}
+
+// SetClientCert 设置客户端证书（双向认证）：通过文件
+func (my *HttpClient) SetClientCert(certFilename, keyFilename string) *HttpClient {
+	cert, err := os.ReadFile(certFilename)
+	if err != nil {
+		my.Err = GenerateCertErr.Wrap(err)
+		return my
+	}
+
+	key, err := os.ReadFile(keyFilename)
+	if err != nil {
+		my.Err = GenerateCertErr.Wrap(err)
+		return my
+	}
+
+	return my.SetClientCertBytes(cert, key)
+}
+
+// SetClientCertBytes 设置客户端证书（双向认证）：通过PEM字节
+func (my *HttpClient) SetClientCertBytes(cert, key []byte) *HttpClient {
+	certificate, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		my.Err = GenerateCertErr.Wrap(err)
+		return my
+	}
+
+	my.clientCerts = append(my.clientCerts, certificate)
+
+	return my
+}
+
+// SetPinnedSpkiHashes 设置证书锁定：服务端证书链中任一证书公钥的sha256摘要（base64）需匹配
+func (my *HttpClient) SetPinnedSpkiHashes(hashes ...string) *HttpClient {
+	my.pinnedSpkiHashes = hashes
+
+	return my
+}
+
+// SetMinTLSVersion 设置最低TLS版本，如：tls.VersionTLS12
+func (my *HttpClient) SetMinTLSVersion(version uint16) *HttpClient {
+	my.minTLSVersion = version
+
+	return my
+}
+
+// SetInsecureSkipVerify 设置跳过服务端证书校验（仅用于内部测试环境）
+func (my *HttpClient) SetInsecureSkipVerify(insecureSkipVerify bool) *HttpClient {
+	my.insecureSkipVerify = insecureSkipVerify
+
+	return my
+}
+
+// SetTLSConfig 设置完整TLS配置：其余TLS设置会在此基础上叠加，生成的传输层保存在客户端上（不进入传输层连接池），需要复用连接时应复用客户端或使用SetTransport
+func (my *HttpClient) SetTLSConfig(tlsConfig *tls.Config) *HttpClient {
+	my.tlsConfig = tlsConfig
+
+	return my
+}
+
+// clientTLSTransport 按完整TLS配置生成的传输层
+type clientTLSTransport struct {
+	config      *tls.Config
+	base        *http.Transport
+	fingerprint string
+	transport   *http.Transport
+}
+
+// SpkiHash 计算证书公钥的sha256摘要（base64），用于证书锁定
+func SpkiHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// hasTLSSettings 是否存在自定义TLS设置
+func (my *HttpClient) hasTLSSettings() bool {
+	return len(my.cert) > 0 ||
+		my.tlsConfig != nil ||
+		len(my.clientCerts) > 0 ||
+		len(my.pinnedSpkiHashes) > 0 ||
+		my.minTLSVersion > 0 ||
+		my.insecureSkipVerify
+}
+
+// tlsFingerprint TLS设置指纹：用于复用相同TLS设置的传输层，不包含完整TLS配置
+func (my *HttpClient) tlsFingerprint() string {
+	hash := sha256.New()
+
+	_, _ = fmt.Fprintf(hash, "%d|%t|%d|", my.minTLSVersion, my.insecureSkipVerify, len(my.cert))
+	hash.Write(my.cert)
+	for _, cert := range my.clientCerts {
+		for _, der := range cert.Certificate {
+			_, _ = fmt.Fprintf(hash, "|%d|", len(der))
+			hash.Write(der)
+		}
+	}
+	for _, pin := range my.pinnedSpkiHashes {
+		_, _ = fmt.Fprintf(hash, "|%s", pin)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// generateTLSConfig 生成TLS配置
+func (my *HttpClient) generateTLSConfig(base *tls.Config) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+
+	switch {
+	case my.tlsConfig != nil:
+		tlsConfig = my.tlsConfig.Clone()
+	case base != nil:
+		tlsConfig = base.Clone()
+	default:
+		tlsConfig = &tls.Config{}
+	}
+
+	// 创建一个新的证书池，并将证书添加到池中
+	if len(my.cert) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(my.cert) {
+			return nil, GenerateCertErr.Panic()
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if len(my.clientCerts) > 0 {
+		tlsConfig.Certificates = append(tlsConfig.Certificates, my.clientCerts...)
+	}
+
+	if my.minTLSVersion > 0 {
+		tlsConfig.MinVersion = my.minTLSVersion
+	}
+
+	if my.insecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if len(my.pinnedSpkiHashes) > 0 {
+		var (
+			pinnedSpkiHashes = my.pinnedSpkiHashes
+			verifyConnection = tlsConfig.VerifyConnection
+		)
+
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if verifyConnection != nil {
+				if err := verifyConnection(state); err != nil {
+					return err
+				}
+			}
+
+			for _, cert := range state.PeerCertificates {
+				if slices.Contains(pinnedSpkiHashes, SpkiHash(cert)) {
+					return nil
+				}
+			}
+
+			return CertPinErr.New(state.ServerName)
+		}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,146 @@
+package httpClient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newClientCert 生成自签名客户端证书
+func newClientCert(t *testing.T) (certPem, keyPem []byte, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成私钥失败：%v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "nova-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("生成证书失败：%v", err)
+	}
+	if cert, err = x509.ParseCertificate(der); err != nil {
+		t.Fatalf("解析证书失败：%v", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("序列化私钥失败：%v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		cert
+}
+
+func Test5TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) }))
+	defer server.Close()
+
+	serverCertPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("TLS：未信任证书", func(t *testing.T) {
+		if hc := NewGet(server.URL).Send(); hc.Err == nil {
+			t.Errorf("期望证书校验失败")
+		}
+	})
+
+	t.Run("TLS：信任根证书", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetCertBytes(serverCertPem).SetMinTLSVersion(tls.VersionTLS12).Send(); hc.Err != nil {
+			t.Errorf("发送失败：%v", hc.Err)
+		}
+	})
+
+	t.Run("TLS：相同设置复用传输层", func(t *testing.T) {
+		var transports []http.RoundTripper
+		for _, hc := range []*HttpClient{
+			NewGet(server.URL).SetCertBytes(serverCertPem),
+			NewGet(server.URL).SetCertBytes(serverCertPem),
+			NewGet(server.URL).SetCertBytes(serverCertPem).SetMinTLSVersion(tls.VersionTLS13),
+		} {
+			transport, err := hc.getTransport()
+			if err != nil {
+				t.Fatalf("获取传输层失败：%v", err)
+			}
+			transports = append(transports, transport)
+		}
+
+		if transports[0] != transports[1] {
+			t.Errorf("相同TLS设置应复用传输层")
+		}
+		if transports[0] == transports[2] {
+			t.Errorf("不同TLS设置不应复用传输层")
+		}
+	})
+
+	t.Run("TLS：跳过校验", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetInsecureSkipVerify(true).Send(); hc.Err != nil {
+			t.Errorf("发送失败：%v", hc.Err)
+		}
+	})
+
+	t.Run("TLS：证书锁定", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetCertBytes(serverCertPem).SetPinnedSpkiHashes(SpkiHash(server.Certificate())).Send(); hc.Err != nil {
+			t.Errorf("证书锁定匹配时发送失败：%v", hc.Err)
+		}
+
+		if hc := NewGet(server.URL).SetCertBytes(serverCertPem).SetPinnedSpkiHashes("bm90LWEtaGFzaA==").Send(); hc.Err == nil {
+			t.Errorf("证书锁定不匹配时应失败")
+		}
+	})
+
+	t.Run("TLS：双向认证", func(t *testing.T) {
+		clientCertPem, clientKeyPem, clientCert := newClientCert(t)
+
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientCert)
+
+		mtlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+		mtlsServer.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+		mtlsServer.StartTLS()
+		defer mtlsServer.Close()
+
+		if hc := NewGet(mtlsServer.URL).SetTLSConfig(mtlsServer.Client().Transport.(*http.Transport).TLSClientConfig).Send(); hc.Err == nil {
+			t.Errorf("未提供客户端证书时应失败")
+		}
+
+		hc := NewGet(mtlsServer.URL).
+			SetTLSConfig(mtlsServer.Client().Transport.(*http.Transport).TLSClientConfig).
+			SetClientCertBytes(clientCertPem, clientKeyPem).
+			Send()
+		if hc.Err != nil {
+			t.Fatalf("发送失败：%v", hc.Err)
+		}
+		if string(hc.GetResponseRawBody()) != "nova-client" {
+			t.Errorf("客户端证书错误：%s", hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("TLS：证书格式错误", func(t *testing.T) {
+		if hc := NewGet(server.URL).SetClientCertBytes([]byte("bad"), []byte("bad")); !errors.Is(hc.Err, &GenerateCertErr) {
+			t.Errorf("期望证书错误，实际：%v", hc.Err)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package httpClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type (
+	// Token 访问令牌
+	Token struct {
+		AccessToken string
+		TokenType   string
+		ExpiresAt   time.Time // 零值表示不过期
+	}
+
+	// TokenSource 令牌来源：需保证并发安全
+	TokenSource interface {
+		// Token 获取有效令牌
+		Token(ctx context.Context) (*Token, error)
+		// Invalidate 令牌被服务端拒绝（401）时作废
+		Invalidate(token *Token)
+	}
+
+	// TokenFetcher 获取新令牌
+	TokenFetcher func(ctx context.Context) (*Token, error)
+
+	// CachedTokenSource 缓存令牌：过期前自动刷新，并发请求共享同一次刷新
+	CachedTokenSource struct {
+		fetcher      TokenFetcher
+		earlyExpiry  time.Duration
+		fetchTimeout time.Duration
+		token        *Token
+		group        singleflight.Group
+		mu           sync.Mutex
+	}
+
+	// ClientCredentials OAuth2客户端凭证模式
+	ClientCredentials struct {
+		tokenUrl     string
+		clientId     string
+		clientSecret string
+		scopes       []string
+		params       map[string]string
+		authInBody   bool
+		timeout      time.Duration
+	}
+
+	// staticTokenSource 固定令牌
+	staticTokenSource struct{ token *Token }
+)
+
+var (
+	CachedTokenSourceApp CachedTokenSource
+	ClientCredentialsApp ClientCredentials
+)
+
+// Valid 令牌是否在指定提前量之前仍有效
+func (my *Token) Valid(earlyExpiry time.Duration) bool {
+	if my == nil || my.AccessToken == "" {
+		return false
+	}
+
+	return my.ExpiresAt.IsZero() || time.Now().Add(earlyExpiry).Before(my.ExpiresAt)
+}
+
+// Authorization 生成Authorization请求头：默认使用Bearer
+func (my *Token) Authorization() string {
+	tokenType := my.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return tokenType + " " + my.AccessToken
+}
+
+// NewStaticTokenSource 实例化：固定令牌来源，如：长期有效的api key
+func NewStaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+// Token 获取令牌
+func (my *staticTokenSource) Token(context.Context) (*Token, error) { return my.token, nil }
+
+// Invalidate 固定令牌无法作废
+func (my *staticTokenSource) Invalidate(*Token) {}
+
+// New 实例化：缓存令牌，默认提前30秒刷新，获取令牌超时30秒
+func (*CachedTokenSource) New(fetcher TokenFetcher) *CachedTokenSource {
+	return &CachedTokenSource{fetcher: fetcher, earlyExpiry: 30 * time.Second, fetchTimeout: 30 * time.Second}
+}
+
+// SetEarlyExpiry 设置提前刷新时间
+func (my *CachedTokenSource) SetEarlyExpiry(earlyExpiry time.Duration) *CachedTokenSource {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.earlyExpiry = earlyExpiry
+
+	return my
+}
+
+// SetFetchTimeout 设置获取令牌超时：获取令牌不受单个调用方取消的影响，仅受该超时限制
+func (my *CachedTokenSource) SetFetchTimeout(fetchTimeout time.Duration) *CachedTokenSource {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.fetchTimeout = fetchTimeout
+
+	return my
+}
+
+// Token 获取有效令牌：缓存的令牌即将过期时重新获取，并发调用共享同一次获取，调用方取消时仅自身返回
+func (my *CachedTokenSource) Token(ctx context.Context) (*Token, error) {
+	my.mu.Lock()
+	token, fetchTimeout := my.token, my.fetchTimeout
+	valid := token.Valid(my.earlyExpiry)
+	my.mu.Unlock()
+
+	if valid {
+		return token, nil
+	}
+
+	result := my.group.DoChan("token", func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		token, err := my.fetcher(fetchCtx)
+		if err != nil {
+			return nil, TokenErr.Wrap(err)
+		}
+		if token == nil || token.AccessToken == "" {
+			return nil, TokenErr.New("令牌为空")
+		}
+
+		my.mu.Lock()
+		my.token = token
+		my.mu.Unlock()
+
+		return token, nil
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*Token), nil
+	case <-ctx.Done():
+		return nil, TokenErr.Wrap(ctx.Err())
+	}
+}
+
+// Invalidate 作废令牌：仅当缓存的仍是该令牌时清除，避免覆盖其他请求已刷新的令牌
+func (my *CachedTokenSource) Invalidate(token *Token) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if my.token != nil && token != nil && my.token.AccessToken == token.AccessToken {
+		my.token = nil
+	}
+}
+
+// New 实例化：OAuth2客户端凭证模式
+func (*ClientCredentials) New(tokenUrl, clientId, clientSecret string) *ClientCredentials {
+	return &ClientCredentials{tokenUrl: tokenUrl, clientId: clientId, clientSecret: clientSecret, params: map[string]string{}}
+}
+
+// SetScopes 设置授权范围
+func (my *ClientCredentials) SetScopes(scopes ...string) *ClientCredentials {
+	my.scopes = scopes
+
+	return my
+}
+
+// SetParams 设置额外参数，如：audience
+func (my *ClientCredentials) SetParams(params map[string]string) *ClientCredentials {
+	my.params = params
+
+	return my
+}
+
+// SetAuthInBody 设置客户端凭证放在请求体中（client_secret_post），默认使用Basic认证（client_secret_basic）
+func (my *ClientCredentials) SetAuthInBody(authInBody bool) *ClientCredentials {
+	my.authInBody = authInBody
+
+	return my
+}
+
+// SetTimeout 设置获取令牌超时
+func (my *ClientCredentials) SetTimeout(timeout time.Duration) *ClientCredentials {
+	my.timeout = timeout
+
+	return my
+}
+
+// Fetch 请求令牌接口获取新令牌
+func (my *ClientCredentials) Fetch(ctx context.Context) (*Token, error) {
+	var (
+		body    = map[string]string{"grant_type": "client_credentials"}
+		content struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+	)
+
+	for k, v := range my.params {
+		body[k] = v
+	}
+	if len(my.scopes) > 0 {
+		body["scope"] = strings.Join(my.scopes, " ")
+	}
+	if my.authInBody {
+		body["client_id"], body["client_secret"] = my.clientId, my.clientSecret
+	}
+
+	hc := NewPost(my.tokenUrl).SetFormBody(body).SetHeaderAccept(AcceptJson).SetTimeout(my.timeout).SetContext(ctx)
+	if !my.authInBody {
+		hc.SetAuthorization(my.clientId, my.clientSecret, "Basic")
+	}
+
+	if hc.Send().Err != nil {
+		return nil, hc.Err
+	}
+	if hc.GetResponse().StatusCode != http.StatusOK {
+		return nil, ResponseStatusErr.New(fmt.Sprintf("%d %s", hc.GetResponse().StatusCode, hc.GetResponseRawBody()))
+	}
+
+	if err := json.Unmarshal(hc.GetResponseRawBody(), &content); err != nil {
+		return nil, UnmarshalJsonErr.Wrap(err)
+	}
+
+	token := &Token{AccessToken: content.AccessToken, TokenType: content.TokenType}
+	if content.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(content.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// TokenSource 生成缓存令牌来源
+func (my *ClientCredentials) TokenSource() *CachedTokenSource {
+	return CachedTokenSourceApp.New(my.Fetch)
+}
+
+// SetTokenSource 设置令牌来源：发送前自动设置Authorization请求头，响应401时刷新令牌并重试一次
+func (my *HttpClient) SetTokenSource(tokenSource TokenSource) *HttpClient {
+	my.tokenSource = tokenSource
+
+	return my
+}
+
+// tokenInterceptor 令牌拦截器
+func tokenInterceptor(tokenSource TokenSource) Interceptor {
+	return func(hc *HttpClient, req *http.Request, next InterceptorNext) (*http.Response, error) {
+		token, err := tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		retryReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retryReq.Body, err = req.GetBody(); err != nil {
+				return nil, GenerateRequestErr.Wrap(err)
+			}
+		}
+
+		req.Header.Set("Authorization", token.Authorization())
+		res, err := next(req)
+		if err != nil || res.StatusCode != http.StatusUnauthorized {
+			return res, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return res, nil // 请求体无法重放
+		}
+
+		tokenSource.Invalidate(token)
+		refreshed, err := tokenSource.Token(req.Context())
+		if err != nil || refreshed.AccessToken == token.AccessToken {
+			return res, nil // 无法刷新时返回原401响应
+		}
+		_ = res.Body.Close()
+
+		retryReq.Header.Set("Authorization", refreshed.Authorization())
+
+		return next(retryReq)
+	}
+}
@@ -0,0 +1,127 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func Test12TokenSource(t *testing.T) {
+	var (
+		issued  atomic.Int32
+		revoked atomic.Value
+	)
+	revoked.Store("")
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, issued.Add(1))
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth == "" || auth == "Bearer "+revoked.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer apiServer.Close()
+
+	t.Run("客户端凭证：并发共享令牌", func(t *testing.T) {
+		var (
+			tokenSource = ClientCredentialsApp.New(tokenServer.URL, "client", "secret").SetScopes("read", "write").TokenSource()
+			wg          sync.WaitGroup
+		)
+
+		issued.Store(0)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if hc := NewGet(apiServer.URL).SetTokenSource(tokenSource).Send(); hc.Err != nil || string(hc.GetResponseRawBody()) != "Bearer token-1" {
+					t.Errorf("请求失败：%v %s", hc.Err, hc.GetResponseRawBody())
+				}
+			}()
+		}
+		wg.Wait()
+
+		if issued.Load() != 1 {
+			t.Errorf("令牌获取次数错误：%d", issued.Load())
+		}
+	})
+
+	t.Run("401时刷新令牌并重试", func(t *testing.T) {
+		tokenSource := ClientCredentialsApp.New(tokenServer.URL, "client", "secret").SetScopes("read", "write").TokenSource()
+
+		issued.Store(0)
+		revoked.Store("token-1")
+		hc := NewPost(apiServer.URL).SetPlainBody("payload").SetTokenSource(tokenSource).Send()
+		if hc.Err != nil || string(hc.GetResponseRawBody()) != "Bearer token-2" {
+			t.Errorf("刷新失败：%v %s", hc.Err, hc.GetResponseRawBody())
+		}
+	})
+
+	t.Run("缓存令牌：调用方取消不影响其他调用方", func(t *testing.T) {
+		var (
+			started     = make(chan struct{})
+			release     = make(chan struct{})
+			fetched     atomic.Int32
+			tokenSource = CachedTokenSourceApp.New(func(ctx context.Context) (*Token, error) {
+				fetched.Add(1)
+				close(started)
+				select {
+				case <-release:
+					return &Token{AccessToken: "shared"}, nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			})
+			ctx, cancel = context.WithCancel(context.Background())
+			canceled    = make(chan error, 1)
+			waited      = make(chan *Token, 1)
+		)
+
+		go func() {
+			_, err := tokenSource.Token(ctx)
+			canceled <- err
+		}()
+		<-started
+		go func() {
+			token, _ := tokenSource.Token(context.Background())
+			waited <- token
+		}()
+
+		cancel()
+		if err := <-canceled; !errors.Is(err, &TokenErr) {
+			t.Errorf("期望取消错误，实际：%v", err)
+		}
+
+		close(release)
+		if token := <-waited; token == nil || token.AccessToken != "shared" {
+			t.Errorf("等待的调用方应获取到令牌：%v", token)
+		}
+		if fetched.Load() != 1 {
+			t.Errorf("令牌获取次数错误：%d", fetched.Load())
+		}
+	})
+
+	t.Run("获取令牌失败", func(t *testing.T) {
+		tokenSource := ClientCredentialsApp.New(tokenServer.URL, "client", "wrong").TokenSource()
+
+		if hc := NewGet(apiServer.URL).SetTokenSource(tokenSource).Send(); !errors.Is(hc.Err, &TokenErr) {
+			t.Errorf("期望获取令牌失败，实际：%v", hc.Err)
+		}
+	})
+}
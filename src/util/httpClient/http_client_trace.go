@@ -0,0 +1,121 @@
+package httpClient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+type (
+	// RequestTiming 请求各阶段耗时：复用连接时DNS、连接与TLS耗时为0
+	RequestTiming struct {
+		DnsLookup        time.Duration // DNS解析
+		TcpConnect       time.Duration // 建立TCP连接
+		TlsHandshake     time.Duration // TLS握手
+		ServerProcessing time.Duration // 请求写入完成至收到首字节
+		TimeToFirstByte  time.Duration // 开始至收到首字节
+		Total            time.Duration // 开始至收到响应头（不含读取响应体）
+		ConnReused       bool          // 是否复用连接
+		RemoteAddr       string        // 服务端地址
+	}
+
+	// requestTracer 通过httptrace记录各阶段时间点
+	requestTracer struct {
+		start, dnsStart, dnsDone, connectStart, connectDone, tlsStart, tlsDone time.Time
+		gotConn, wroteRequest, firstByte                                       time.Time
+		remoteAddr                                                             string
+		connReused                                                             bool
+		mu                                                                     sync.Mutex
+	}
+)
+
+// newRequestTracer 实例化：请求追踪
+func newRequestTracer() *requestTracer { return &requestTracer{start: time.Now()} }
+
+// GetTiming 获取最近一次发送的各阶段耗时（重试时为最后一次尝试）
+func (my *HttpClient) GetTiming() *RequestTiming { return my.timing }
+
+// traceRequest 追踪请求耗时并记录指标
+func (my *HttpClient) traceRequest(req *http.Request, terminal InterceptorNext) (*http.Response, error) {
+	tracer := newRequestTracer()
+
+	res, err := my.intercept(req.WithContext(httptrace.WithClientTrace(req.Context(), tracer.trace())), terminal)
+
+	timing := tracer.timing(time.Now())
+	my.timing = &timing
+
+	if my.metrics != nil {
+		my.metrics.Observe(req.URL.Host, res, err, timing.Total)
+	}
+
+	return res, err
+}
+
+// trace 生成httptrace钩子
+func (my *requestTracer) trace() *httptrace.ClientTrace {
+	record := func(t *time.Time) {
+		my.mu.Lock()
+		defer my.mu.Unlock()
+		*t = time.Now()
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { record(&my.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { record(&my.dnsDone) },
+		ConnectStart:         func(string, string) { record(&my.connectStart) },
+		ConnectDone:          func(string, string, error) { record(&my.connectDone) },
+		TLSHandshakeStart:    func() { record(&my.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { record(&my.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { record(&my.wroteRequest) },
+		GotFirstResponseByte: func() { record(&my.firstByte) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			record(&my.gotConn)
+
+			my.mu.Lock()
+			defer my.mu.Unlock()
+			my.connReused = info.Reused
+			if info.Conn != nil {
+				my.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+		},
+	}
+}
+
+// ip 服务端地址
+func (my *requestTracer) ip() string {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if host, _, err := net.SplitHostPort(my.remoteAddr); err == nil {
+		return host
+	}
+
+	return my.remoteAddr
+}
+
+// timing 计算各阶段耗时
+func (my *requestTracer) timing(end time.Time) RequestTiming {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	since := func(from, to time.Time) time.Duration {
+		if from.IsZero() || to.IsZero() || to.Before(from) {
+			return 0
+		}
+		return to.Sub(from)
+	}
+
+	return RequestTiming{
+		DnsLookup:        since(my.dnsStart, my.dnsDone),
+		TcpConnect:       since(my.connectStart, my.connectDone),
+		TlsHandshake:     since(my.tlsStart, my.tlsDone),
+		ServerProcessing: since(my.wroteRequest, my.firstByte),
+		TimeToFirstByte:  since(my.start, my.firstByte),
+		Total:            since(my.start, end),
+		ConnReused:       my.connReused,
+		RemoteAddr:       my.remoteAddr,
+	}
+}
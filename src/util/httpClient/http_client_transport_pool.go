@@ -0,0 +1,274 @@
+package httpClient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jericho-yu/nova/src/util/dict"
+)
+
+type (
+	// TransportConfig 传输层配置：零值表示使用http.DefaultTransport的默认值
+	TransportConfig struct {
+		MaxIdleConns          int
+		MaxIdleConnsPerHost   int
+		MaxConnsPerHost       int
+		IdleConnTimeout       time.Duration
+		DialTimeout           time.Duration
+		KeepAlive             time.Duration
+		TLSHandshakeTimeout   time.Duration
+		ResponseHeaderTimeout time.Duration
+		Proxy                 string
+		DisableHttp2          bool
+		DisableKeepAlives     bool
+	}
+
+	// TransportPool 传输层连接池：按名称复用http.Transport，带TLS设置的传输层按名称与TLS设置指纹复用（最多保留maxTLSTransports个，超出时淘汰最早生成的）
+	TransportPool struct {
+		transports    *dict.AnyDict[string, *http.Transport]
+		tlsTransports *dict.AnyDict[string, *http.Transport]
+		tlsKeys       []string // TLS传输层的生成顺序，由tlsMu保护
+		tlsMu         sync.Mutex
+	}
+)
+
+const maxTLSTransports = 64 // 连接池中TLS传输层的数量上限
+
+var (
+	transportPoolIns  *TransportPool
+	transportPoolOnce sync.Once
+	TransportPoolApp  TransportPool
+)
+
+// Once 单例化：传输层连接池
+func (*TransportPool) Once() *TransportPool {
+	transportPoolOnce.Do(func() {
+		transportPoolIns = &TransportPool{transports: dict.Make[string, *http.Transport](), tlsTransports: dict.Make[string, *http.Transport]()}
+	})
+
+	return transportPoolIns
+}
+
+// Set 设置传输层：同名传输层会被替换并关闭空闲连接
+func (*TransportPool) Set(name string, transportConfig *TransportConfig) (*TransportPool, error) {
+	transport, err := transportConfig.build()
+	if err != nil {
+		return transportPoolIns, err
+	}
+
+	if old, exist := transportPoolIns.transports.Get(name); exist {
+		old.CloseIdleConnections()
+	}
+	transportPoolIns.transports.Set(name, transport)
+	transportPoolIns.removeTLS(name)
+
+	return transportPoolIns, nil
+}
+
+// Has 检查传输层是否存在
+func (*TransportPool) Has(name string) bool { return transportPoolIns.transports.HasKey(name) }
+
+// Get 获取传输层
+func (*TransportPool) Get(name string) *http.Transport {
+	if transport, exist := transportPoolIns.transports.Get(name); exist {
+		return transport
+	}
+
+	return nil
+}
+
+// Remove 删除传输层并关闭空闲连接
+func (*TransportPool) Remove(name string) *TransportPool {
+	if transport, exist := transportPoolIns.transports.Get(name); exist {
+		transport.CloseIdleConnections()
+		transportPoolIns.transports.RemoveByKey(name)
+	}
+	transportPoolIns.removeTLS(name)
+
+	return transportPoolIns
+}
+
+// Clean 清空传输层
+func (*TransportPool) Clean() *TransportPool {
+	for _, name := range transportPoolIns.transports.GetKeys().ToSlice() {
+		transportPoolIns.Remove(name)
+	}
+	transportPoolIns.removeTLS("")
+
+	return transportPoolIns
+}
+
+// getTLS 获取TLS传输层：相同传输层名称与TLS设置指纹复用同一传输层，不存在时通过generate生成
+func (*TransportPool) getTLS(name, fingerprint string, generate func() (*http.Transport, error)) (*http.Transport, error) {
+	transportPoolIns.tlsMu.Lock()
+	defer transportPoolIns.tlsMu.Unlock()
+
+	key := name + "|" + fingerprint
+	if transport, exist := transportPoolIns.tlsTransports.Get(key); exist {
+		return transport, nil
+	}
+
+	transport, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transportPoolIns.tlsKeys) >= maxTLSTransports {
+		transportPoolIns.evictTLS(transportPoolIns.tlsKeys[0])
+	}
+	transportPoolIns.tlsTransports.Set(key, transport)
+	transportPoolIns.tlsKeys = append(transportPoolIns.tlsKeys, key)
+
+	return transport, nil
+}
+
+// removeTLS 删除基于指定传输层生成的TLS传输层并关闭空闲连接
+func (*TransportPool) removeTLS(name string) {
+	transportPoolIns.tlsMu.Lock()
+	defer transportPoolIns.tlsMu.Unlock()
+
+	for _, key := range slices.Clone(transportPoolIns.tlsKeys) {
+		if strings.HasPrefix(key, name+"|") {
+			transportPoolIns.evictTLS(key)
+		}
+	}
+}
+
+// evictTLS 淘汰TLS传输层并关闭空闲连接：调用方需持有tlsMu
+func (*TransportPool) evictTLS(key string) {
+	if transport, exist := transportPoolIns.tlsTransports.Get(key); exist {
+		transport.CloseIdleConnections()
+	}
+	transportPoolIns.tlsTransports.RemoveByKey(key)
+	transportPoolIns.tlsKeys = slices.DeleteFunc(transportPoolIns.tlsKeys, func(k string) bool { return k == key })
+}
+
+// build 根据配置生成传输层
+func (my *TransportConfig) build() (*http.Transport, error) {
+	var (
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		dialer    = &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	)
+
+	if my == nil {
+		return transport, nil
+	}
+
+	if my.DialTimeout > 0 {
+		dialer.Timeout = my.DialTimeout
+	}
+	if my.KeepAlive != 0 {
+		dialer.KeepAlive = my.KeepAlive
+	}
+	transport.DialContext = dialer.DialContext
+
+	if my.MaxIdleConns > 0 {
+		transport.MaxIdleConns = my.MaxIdleConns
+	}
+	if my.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = my.MaxIdleConnsPerHost
+	}
+	if my.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = my.MaxConnsPerHost
+	}
+	if my.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = my.IdleConnTimeout
+	}
+	if my.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = my.TLSHandshakeTimeout
+	}
+	if my.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = my.ResponseHeaderTimeout
+	}
+	transport.DisableKeepAlives = my.DisableKeepAlives
+
+	if my.Proxy != "" {
+		proxyUrl, err := url.Parse(my.Proxy)
+		if err != nil {
+			return nil, SetTransportErr.Wrap(err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if my.DisableHttp2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(authority string, c *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport, nil
+}
+
+// SetTransportName 设置使用传输层连接池中的传输层
+func (my *HttpClient) SetTransportName(name string) *HttpClient {
+	my.transportName = name
+
+	return my
+}
+
+// SetTransport 设置传输层
+func (my *HttpClient) SetTransport(transport http.RoundTripper) *HttpClient {
+	my.transport = transport
+
+	return my
+}
+
+// getTransport 获取传输层：自定义传输层 > 连接池传输层 > 默认传输层；设置了完整TLS配置时，传输层保存在客户端上，不进入连接池
+func (my *HttpClient) getTransport() (http.RoundTripper, error) {
+	if my.transport != nil {
+		return my.transport, nil
+	}
+
+	var base *http.Transport
+	if my.transportName != "" {
+		if base = TransportPoolApp.Once().Get(my.transportName); base == nil {
+			return nil, TransportNotFoundErr.New(my.transportName)
+		}
+	}
+
+	if !my.hasTLSSettings() {
+		if base == nil {
+			return http.DefaultTransport, nil
+		}
+		return base, nil
+	}
+
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+
+	generate := func() (*http.Transport, error) {
+		tlsConfig, err := my.generateTLSConfig(base.TLSClientConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		transport := base.Clone()
+		transport.TLSClientConfig = tlsConfig
+
+		return transport, nil
+	}
+
+	if my.tlsConfig == nil {
+		return TransportPoolApp.Once().getTLS(my.transportName, my.tlsFingerprint(), generate)
+	}
+
+	// 完整TLS配置无法按内容区分，同一客户端（及其副本）在配置不变时复用
+	fingerprint := my.tlsFingerprint()
+	if cached := my.tlsTransport; cached != nil && cached.config == my.tlsConfig && cached.base == base && cached.fingerprint == fingerprint {
+		return cached.transport, nil
+	}
+
+	transport, err := generate()
+	if err != nil {
+		return nil, err
+	}
+	my.tlsTransport = &clientTLSTransport{config: my.tlsConfig, base: base, fingerprint: fingerprint, transport: transport}
+
+	return transport, nil
+}
@@ -2,14 +2,16 @@ package retry
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"time"
 )
 
 type Retry struct {
-	sleep time.Duration
-	fn    func() error
-	ctx   context.Context
+	sleep    time.Duration
+	maxSleep time.Duration
+	fn       func() error
+	ctx      context.Context
 }
 
 var RetryApp Retry
@@ -17,13 +19,23 @@ var RetryApp Retry
 // New 实例化：重试器
 func (*Retry) New() *Retry { return &Retry{sleep: time.Second, fn: nil, ctx: context.TODO()} }
 
-// SetSleep 设置重试间隔
+// SetSleep 设置重试间隔：超过最大间隔时取最大间隔
 func (my *Retry) SetSleep(sleep time.Duration) *Retry {
+	if my.maxSleep > 0 && sleep > my.maxSleep {
+		sleep = my.maxSleep
+	}
 	my.sleep = sleep
 
 	return my
 }
 
+// SetMaxSleep 设置最大重试间隔：指数退避不会超过该值，0表示不限制
+func (my *Retry) SetMaxSleep(maxSleep time.Duration) *Retry {
+	my.maxSleep = maxSleep
+
+	return my.SetSleep(my.sleep)
+}
+
 // SetFn 设置重试方法
 func (my *Retry) SetFn(fn func() error) *Retry {
 	my.fn = fn
@@ -76,26 +88,33 @@ func (my *Retry) WithContext(attempts int) error {
 	return nil
 }
 
+// WithContextAndJitter 带上下文的随机退避重试：在（按最大间隔截断后的）重试间隔上增加不超过其一半的随机抖动，抖动不计入下次的退避间隔
 func (my *Retry) WithContextAndJitter(attempts int) error {
 	if my.fn == nil {
 		return nil
 	}
 
-	if err := my.fn(); err != nil {
-		if attempts--; attempts > 0 {
-			// 加入随机退避
-			jitter := time.Duration(rand.Int63n(int64(my.sleep)))
-			my.sleep = my.sleep + jitter
+	for {
+		err := my.fn()
+		if err == nil {
+			return nil
+		}
+		if attempts--; attempts <= 0 {
+			return err
+		}
 
-			select {
-			case <-time.After(my.sleep):
-				return my.SetSleep(2 * my.sleep).WithContextAndJitter(attempts) // 指数退避
-			case <-my.ctx.Done():
-				return my.ctx.Err()
+		sleep := my.sleep
+		if half := int64(sleep / 2); half > 0 {
+			sleep += time.Duration(rand.Int63n(half))
+		}
+
+		select {
+		case <-time.After(sleep):
+			if my.sleep < math.MaxInt64/2 {
+				my.SetSleep(2 * my.sleep) // 指数退避
 			}
+		case <-my.ctx.Done():
+			return my.ctx.Err()
 		}
-		return err
 	}
-
-	return nil
 }
@@ -45,3 +45,47 @@ func Test3(t *testing.T) {
 		}
 	})
 }
+
+func Test4(t *testing.T) {
+	t.Run("test4 最大重试间隔", func(t *testing.T) {
+		var (
+			count int
+			start = time.Now()
+		)
+
+		err := RetryApp.New().SetSleep(20 * time.Millisecond).SetMaxSleep(30 * time.Millisecond).SetFn(func() error {
+			count++
+			return errors.New("transient error")
+		}).WithContextAndJitter(5)
+		if err == nil || count != 5 {
+			t.Fatalf("重试次数错误：%d %v", count, err)
+		}
+
+		// 不限制时至少需要 20+40+80+160 毫秒
+		if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+			t.Errorf("超过最大重试间隔：%s", elapsed)
+		}
+	})
+}
+
+func Test5(t *testing.T) {
+	t.Run("test5 随机抖动不计入退避间隔", func(t *testing.T) {
+		var (
+			count int
+			start = time.Now()
+		)
+
+		err := RetryApp.New().SetSleep(40 * time.Millisecond).SetFn(func() error {
+			count++
+			return errors.New("transient error")
+		}).WithContextAndJitter(3)
+		if err == nil || count != 3 {
+			t.Fatalf("重试次数错误：%d %v", count, err)
+		}
+
+		// 间隔为40、80毫秒，抖动不超过各自的一半
+		if elapsed := time.Since(start); elapsed < 120*time.Millisecond || elapsed > 220*time.Millisecond {
+			t.Errorf("重试间隔错误：%s", elapsed)
+		}
+	})
+}
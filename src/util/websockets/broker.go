@@ -0,0 +1,232 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jericho-yu/nova/src/util/redisPool"
+
+	"github.com/google/uuid"
+	rds "github.com/redis/go-redis/v9"
+)
+
+type (
+	// BrokerKind 转发消息的目标类型
+	BrokerKind string
+
+	// BrokerMessage 转发消息：在多个实例之间传递ServerPool的发送请求
+	BrokerMessage struct {
+		NodeId  string     `json:"nodeId"` // 发送实例，实例会忽略自己发出的消息
+		Kind    BrokerKind `json:"kind"`
+		Target  string     `json:"target"`
+		Payload []byte     `json:"payload"`
+	}
+
+	// Broker 消息代理：ServerPool通过消息代理把发送请求转发到集群中的其他实例
+	Broker interface {
+		Publish(ctx context.Context, message BrokerMessage) error
+		// Subscribe 订阅转发消息：handler在代理内部的协程中按顺序执行，不应阻塞
+		Subscribe(handler func(message BrokerMessage)) error
+		Close() error
+	}
+
+	// MemoryBroker 内存消息代理：同一进程内的订阅者之间转发，适用于单实例与测试
+	MemoryBroker struct {
+		handlers []func(message BrokerMessage)
+		mu       sync.RWMutex
+	}
+
+	// RedisBroker redis消息代理：基于redis发布订阅
+	RedisBroker struct {
+		client  *rds.Client
+		channel string
+		pubSub  *rds.PubSub
+		mu      sync.Mutex
+	}
+)
+
+var (
+	MemoryBrokerApp MemoryBroker
+	RedisBrokerApp  RedisBroker
+
+	BrokerToAuthId BrokerKind = "authId"
+	BrokerToRoom   BrokerKind = "room"
+)
+
+// New 实例化：内存消息代理
+func (*MemoryBroker) New() *MemoryBroker { return &MemoryBroker{} }
+
+// Publish 发布：同步分发给全部订阅者
+func (my *MemoryBroker) Publish(_ context.Context, message BrokerMessage) error {
+	my.mu.RLock()
+	handlers := append([]func(message BrokerMessage){}, my.handlers...)
+	my.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+
+	return nil
+}
+
+// Subscribe 订阅
+func (my *MemoryBroker) Subscribe(handler func(message BrokerMessage)) error {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.handlers = append(my.handlers, handler)
+
+	return nil
+}
+
+// Close 关闭：清空订阅者
+func (my *MemoryBroker) Close() error {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.handlers = nil
+
+	return nil
+}
+
+// New 实例化：redis消息代理
+func (*RedisBroker) New(client *rds.Client, channel string) *RedisBroker {
+	return &RedisBroker{client: client, channel: channel}
+}
+
+// NewByPool 实例化：redis消息代理，使用redisPool中的链接，频道名会加上链接前缀（需先初始化redisPool）
+func (*RedisBroker) NewByPool(clientName, channel string) (*RedisBroker, error) {
+	prefix, client := redisPool.RedisPoolApp.GetClient(clientName)
+	if client == nil {
+		return nil, WebsocketBrokerErr.New(fmt.Sprintf("没有找到redis链接：%s", clientName))
+	}
+
+	return RedisBrokerApp.New(client, fmt.Sprintf("%s:%s", prefix, channel)), nil
+}
+
+// Publish 发布
+func (my *RedisBroker) Publish(ctx context.Context, message BrokerMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return WebsocketBrokerErr.Wrap(err)
+	}
+
+	if err = my.client.Publish(ctx, my.channel, data).Err(); err != nil {
+		return WebsocketBrokerErr.Wrap(err)
+	}
+
+	return nil
+}
+
+// Subscribe 订阅：确认订阅成功后返回，无法解析的消息会被忽略
+func (my *RedisBroker) Subscribe(handler func(message BrokerMessage)) error {
+	pubSub := my.client.Subscribe(context.Background(), my.channel)
+	if _, err := pubSub.Receive(context.Background()); err != nil {
+		_ = pubSub.Close()
+		return WebsocketBrokerErr.Wrap(err)
+	}
+
+	my.mu.Lock()
+	my.pubSub = pubSub
+	my.mu.Unlock()
+
+	go func() {
+		for received := range pubSub.Channel() {
+			var message BrokerMessage
+			if err := json.Unmarshal([]byte(received.Payload), &message); err == nil {
+				handler(message)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close 关闭订阅
+func (my *RedisBroker) Close() error {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if my.pubSub == nil {
+		return nil
+	}
+
+	err := my.pubSub.Close()
+	my.pubSub = nil
+
+	return err
+}
+
+// SetBroker 设置消息代理：按认证ID、房间发送的消息会同时转发到集群中的其他实例，为空时取消转发（不会关闭原消息代理）
+func (*ServerPool) SetBroker(broker Broker) error {
+	serverPool.brokerMu.Lock()
+	defer serverPool.brokerMu.Unlock()
+
+	if broker == nil {
+		serverPool.broker = nil
+		return nil
+	}
+
+	if serverPool.nodeId == "" {
+		serverPool.nodeId = uuid.Must(uuid.NewV6()).String()
+	}
+
+	// 被替换的消息代理收到的消息不再处理
+	if err := broker.Subscribe(func(message BrokerMessage) {
+		if serverPool.getBroker() == broker {
+			serverPool.receiveBrokerMessage(message)
+		}
+	}); err != nil {
+		return err
+	}
+	serverPool.broker = broker
+
+	return nil
+}
+
+// getBroker 获取消息代理
+func (*ServerPool) getBroker() Broker {
+	serverPool.brokerMu.RLock()
+	defer serverPool.brokerMu.RUnlock()
+
+	return serverPool.broker
+}
+
+// GetNodeId 获取实例编号：设置消息代理后生成
+func (*ServerPool) GetNodeId() string { return serverPool.nodeId }
+
+// publish 转发消息到其他实例：未设置消息代理时返回false
+func (*ServerPool) publish(message BrokerMessage) bool {
+	broker := serverPool.getBroker()
+	if broker == nil {
+		return false
+	}
+
+	message.NodeId = serverPool.nodeId
+	if err := broker.Publish(context.Background(), message); err != nil && serverPool.onSendMessageFail != nil {
+		serverPool.onSendMessageFail(err)
+	}
+
+	return true
+}
+
+// receiveBrokerMessage 处理其他实例转发的消息：仅发送给本实例的连接，消息进入各连接的发送队列，避免慢连接阻塞消息代理的接收协程
+func (*ServerPool) receiveBrokerMessage(message BrokerMessage) {
+	if message.NodeId == serverPool.nodeId {
+		return
+	}
+
+	var servers []*Server
+	switch message.Kind {
+	case BrokerToAuthId:
+		servers = serverPool.localServersByAuthId(message.Target)
+	case BrokerToRoom:
+		servers = serverPool.rooms.servers(message.Target)
+	}
+
+	for _, server := range servers {
+		server.enqueue(message.Payload, serverPool.onSendMessageSuccess, serverPool.onSendMessageFail)
+	}
+}
@@ -0,0 +1,322 @@
+package websockets
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	rds "github.com/redis/go-redis/v9"
+)
+
+// testRedisServer 本地redis替身：仅支持发布订阅所需的命令（SUBSCRIBE、UNSUBSCRIBE、PUBLISH、PING）
+type testRedisServer struct {
+	listener    net.Listener
+	subscribers map[string]map[*testRedisConn]struct{}
+	mu          sync.Mutex
+}
+
+type testRedisConn struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+func newTestRedisServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败：%v", err)
+	}
+
+	server := &testRedisServer{listener: listener, subscribers: map[string]map[*testRedisConn]struct{}{}}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(&testRedisConn{conn: conn})
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func (my *testRedisServer) serve(conn *testRedisConn) {
+	defer func() {
+		my.mu.Lock()
+		for _, conns := range my.subscribers {
+			delete(conns, conn)
+		}
+		my.mu.Unlock()
+		_ = conn.conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn.conn)
+	for {
+		args, err := readTestRedisCommand(reader)
+		if err != nil {
+			return
+		}
+
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			conn.write("+PONG\r\n")
+		case "SUBSCRIBE", "UNSUBSCRIBE":
+			kind := strings.ToLower(args[0])
+			for _, channel := range args[1:] {
+				my.mu.Lock()
+				if kind == "subscribe" {
+					if my.subscribers[channel] == nil {
+						my.subscribers[channel] = map[*testRedisConn]struct{}{}
+					}
+					my.subscribers[channel][conn] = struct{}{}
+				} else {
+					delete(my.subscribers[channel], conn)
+				}
+				my.mu.Unlock()
+				conn.write(fmt.Sprintf("*3\r\n%s%s:1\r\n", bulk(kind), bulk(channel)))
+			}
+		case "PUBLISH":
+			my.mu.Lock()
+			var receivers []*testRedisConn
+			for subscriber := range my.subscribers[args[1]] {
+				receivers = append(receivers, subscriber)
+			}
+			my.mu.Unlock()
+
+			for _, receiver := range receivers {
+				receiver.write(fmt.Sprintf("*3\r\n%s%s%s", bulk("message"), bulk(args[1]), bulk(args[2])))
+			}
+			conn.write(fmt.Sprintf(":%d\r\n", len(receivers)))
+		default: // HELLO、CLIENT SETINFO等返回错误，客户端会降级处理
+			conn.write("-ERR unknown command '" + args[0] + "'\r\n")
+		}
+	}
+}
+
+func (my *testRedisConn) write(reply string) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	_, _ = io.WriteString(my.conn, reply)
+}
+
+func bulk(value string) string { return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n" }
+
+// readTestRedisCommand 读取RESP数组格式的命令
+func readTestRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for range count {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args = append(args, string(data[:size]))
+	}
+
+	return args, nil
+}
+
+// TestBroker1Cluster 测试：通过消息代理跨实例发送
+func TestBroker1Cluster(t *testing.T) {
+	pool, addr := newTestServerPool(t)
+	t.Cleanup(func() { _ = pool.SetBroker(nil) })
+
+	alice := dialTestClient(t, addr, "alice")
+	request(t, alice, "join:cluster")
+
+	newRedisBroker := func(t *testing.T, redisAddr string) *RedisBroker {
+		client := rds.NewClient(&rds.Options{Addr: redisAddr})
+		t.Cleanup(func() { _ = client.Close() })
+		return RedisBrokerApp.New(client, "nova:websocket")
+	}
+	redisAddr := newTestRedisServer(t)
+
+	for name, brokers := range map[string]func(t *testing.T) (local, remote Broker){
+		"内存": func(t *testing.T) (Broker, Broker) {
+			broker := MemoryBrokerApp.New()
+			return broker, broker
+		},
+		"redis": func(t *testing.T) (Broker, Broker) {
+			return newRedisBroker(t, redisAddr), newRedisBroker(t, redisAddr)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			local, remote := brokers(t)
+			if err := pool.SetBroker(local); err != nil {
+				t.Fatalf("设置消息代理失败：%v", err)
+			}
+			defer func() { _ = local.Close(); _ = remote.Close() }()
+
+			published := make(chan BrokerMessage, 10)
+			if err := remote.Subscribe(func(message BrokerMessage) {
+				if message.NodeId != "remote" {
+					published <- message
+				}
+			}); err != nil {
+				t.Fatalf("订阅失败：%v", err)
+			}
+
+			t.Run("本实例没有的连接转发到其他实例", func(t *testing.T) {
+				authId, target := "bob", "10.0.0.1:1234"
+				pool.SendMessageByAddr(&target, []byte("to-addr")) // 地址只在本实例有意义，不转发
+				pool.SendMessageByAuthId(&authId, []byte("to-bob"))
+
+				for _, expect := range []BrokerMessage{
+					{Kind: BrokerToAuthId, Target: "bob", Payload: []byte("to-bob")},
+				} {
+					select {
+					case message := <-published:
+						if message.NodeId != pool.GetNodeId() || message.Kind != expect.Kind || message.Target != expect.Target || string(message.Payload) != string(expect.Payload) {
+							t.Errorf("转发消息错误：%+v", message)
+						}
+					case <-time.After(2 * time.Second):
+						t.Fatal("等待转发超时")
+					}
+				}
+			})
+
+			t.Run("其他实例转发的消息发送给本实例连接", func(t *testing.T) {
+				for _, message := range []BrokerMessage{
+					{NodeId: pool.GetNodeId(), Kind: BrokerToAuthId, Target: "alice", Payload: []byte("self")}, // 自己发出的消息被忽略
+					{NodeId: "remote", Kind: BrokerToAuthId, Target: "alice", Payload: []byte("by-auth")},
+					{NodeId: "remote", Kind: BrokerToRoom, Target: "cluster", Payload: []byte("by-room")},
+				} {
+					if err := remote.Publish(context.Background(), message); err != nil {
+						t.Fatalf("发布失败：%v", err)
+					}
+				}
+
+				for _, expect := range []string{"by-auth", "by-room"} {
+					if message := readTestMessage(t, alice); string(message.GetMessage()) != expect {
+						t.Errorf("消息错误：期望%s，实际%s", expect, message.GetMessage())
+					}
+				}
+			})
+		})
+	}
+
+	t.Run("取消消息代理", func(t *testing.T) {
+		_ = pool.SetBroker(nil)
+		if err := alice.WriteMessage(websocket.TextMessage, []byte("say:nobody:x")); err != nil {
+			t.Fatalf("发送失败：%v", err)
+		}
+		if message := readTestMessage(t, alice); string(message.GetMessage()) != "ok" {
+			t.Errorf("消息错误：%s", message.GetMessage())
+		}
+	})
+}
+
+// TestBroker2SlowConnection 测试：慢连接不阻塞消息代理的分发
+func TestBroker2SlowConnection(t *testing.T) {
+	pool, addr := newTestServerPool(t)
+	t.Cleanup(func() { _ = pool.SetBroker(nil) })
+
+	broker := MemoryBrokerApp.New()
+	if err := pool.SetBroker(broker); err != nil {
+		t.Fatalf("设置消息代理失败：%v", err)
+	}
+
+	var (
+		slow  = dialTestClient(t, addr, "slow")
+		alice = dialTestClient(t, addr, "alice")
+	)
+	request(t, slow, "join:fanout") // 之后不再读取，写缓冲区被占满后服务端写入阻塞
+	request(t, alice, "join:fanout")
+
+	const count = 200
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		payload := []byte(strings.Repeat("x", 64*1024))
+		for range count {
+			_ = broker.Publish(context.Background(), BrokerMessage{NodeId: "remote", Kind: BrokerToRoom, Target: "fanout", Payload: payload})
+		}
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(2 * time.Second):
+		t.Fatal("分发被慢连接阻塞")
+	}
+
+	for range count {
+		if message := readTestMessage(t, alice); len(message.GetMessage()) != 64*1024 {
+			t.Fatalf("消息错误：%d", len(message.GetMessage()))
+		}
+	}
+}
+
+// TestBroker3QueueCallbacks 测试：发送队列中的消息执行各自的回调
+func TestBroker3QueueCallbacks(t *testing.T) {
+	servers := make(chan *Server, 1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		server := ServerApp.New(conn)
+		server.setStatus(Online)
+		servers <- server
+	}))
+	t.Cleanup(httpServer.Close)
+
+	conn := dialTestClient(t, "ws"+strings.TrimPrefix(httpServer.URL, "http"), "queue")
+	server := <-servers
+	t.Cleanup(func() { _ = server.conn.Close() })
+
+	var (
+		sent      = make(chan string, 10)
+		onSuccess = func(name string) serverSendMessageSuccessFn {
+			return func(conn *websocket.Conn, message, prototypeMessage []byte) {
+				sent <- name + ":" + string(prototypeMessage)
+			}
+		}
+	)
+	for _, name := range []string{"a", "b", "c"} {
+		server.enqueue([]byte(name), onSuccess(name), nil)
+	}
+
+	for _, expect := range []string{"a:a", "b:b", "c:c"} {
+		if message := readTestMessage(t, conn); string(message.GetMessage()) != expect[2:] {
+			t.Errorf("消息顺序错误：%s", message.GetMessage())
+		}
+		select {
+		case name := <-sent:
+			if name != expect {
+				t.Errorf("回调错误：期望%s，实际%s", expect, name)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("等待回调超时")
+		}
+	}
+}
@@ -2,6 +2,8 @@ package websockets
 
 import (
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jericho-yu/nova/src/util/dict"
@@ -29,6 +31,11 @@ type (
 		onReceiveMessageSuccessCallback clientReceiveMessageSuccessFn
 		onReceiveMessageFailCallback    clientStandardFailFn
 		onSendMessageFailCallback       clientStandardFailFn
+		onStatusChangeCallback          clientStatusChangeFn
+		reconnect                       atomic.Pointer[clientReconnect]
+		closing                         atomic.Bool
+		statusMu                        sync.RWMutex
+		writeMu                         sync.Mutex
 	}
 )
 
@@ -86,7 +93,35 @@ func NewClient(
 }
 
 // GetStatus 获取链接状态
-func (my *Client) GetStatus() WebsocketConnStatus { return my.status }
+func (my *Client) GetStatus() WebsocketConnStatus {
+	my.statusMu.RLock()
+	defer my.statusMu.RUnlock()
+
+	return my.status
+}
+
+// setStatus 设置链接状态：状态变化时执行回调
+func (my *Client) setStatus(status WebsocketConnStatus) {
+	my.notifyStatus(my.swapStatus(status), status)
+}
+
+// swapStatus 设置链接状态并返回原状态：不执行回调
+func (my *Client) swapStatus(status WebsocketConnStatus) WebsocketConnStatus {
+	my.statusMu.Lock()
+	defer my.statusMu.Unlock()
+
+	from := my.status
+	my.status = status
+
+	return from
+}
+
+// notifyStatus 状态变化时执行回调
+func (my *Client) notifyStatus(from, to WebsocketConnStatus) {
+	if from != to && my.onStatusChangeCallback != nil {
+		my.onStatusChangeCallback(my.groupName, my.name, from, to)
+	}
+}
 
 // GetName 获取链接名称
 func (my *Client) GetName() string { return my.name }
@@ -130,63 +165,113 @@ func (my *Client) AppendRequestHeader(header http.Header) *Client {
 	return my
 }
 
-// Boot 启动链接，并打开监听
+// Boot 启动链接，并打开监听：开启自动重连时，启动失败也会进入重连
 func (my *Client) Boot() *Client {
-	var (
-		receiveMessage []byte
-		messageType    int
-	)
+	my.closing.Store(false)
 
-	my.conn, _, my.err = websocket.DefaultDialer.Dial(my.addr, my.requestHeader)
-	if my.err != nil {
-		if my.onConnFailCallback != nil {
-			my.onConnFailCallback(my.groupName, my.name, my.conn, my.err)
+	conn, err := my.dial()
+	if err != nil {
+		my.err = err
+		if reconnect := my.reconnect.Load(); reconnect != nil {
+			go my.reconnectLoop(reconnect)
 		}
 
 		return my
 	}
 
+	my.setStatus(Online)
+	go my.listen(conn)
+
+	return my
+}
+
+// dial 建立链接
+func (my *Client) dial() (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(my.addr, my.requestHeader)
+	if err != nil {
+		if my.onConnFailCallback != nil {
+			my.onConnFailCallback(my.groupName, my.name, conn, err)
+		}
+
+		return nil, err
+	}
+
+	my.writeMu.Lock()
+	my.conn = conn
+	my.writeMu.Unlock()
+
 	if my.onConnSuccessCallback != nil {
-		my.onConnSuccessCallback(my.groupName, my.name, my.conn)
+		my.onConnSuccessCallback(my.groupName, my.name, conn)
 	}
 
-	// 开启监听
-	go func(client *Client) {
-		for {
-			messageType, receiveMessage, client.err = client.conn.ReadMessage()
-			if client.err != nil {
-				if client.onReceiveMessageFailCallback != nil {
-					client.onReceiveMessageFailCallback(client.groupName, client.name, client.conn, client.err)
-				}
+	return conn, nil
+}
 
+// listen 监听消息：读取失败时，若开启了自动重连且不是主动关闭，则进入重连，否则标记为离线
+func (my *Client) listen(conn *websocket.Conn) {
+	for {
+		messageType, receiveMessage, err := conn.ReadMessage()
+		if err != nil {
+			if my.closing.Load() {
 				return
 			}
 
-			switch messageType {
-			case websocket.TextMessage, websocket.BinaryMessage:
-				// 解析消息
-				message := ParseMessage(receiveMessage)
-				client.onReceiveMessageSuccessCallback(client.groupName, client.name, message.GetMessage())
-				if message.GetAsync() { // 异步消息
-					if callback, ok := client.asyncReceiveCallbackDict.Get(message.GetMessageId()); ok {
-						callback(client.groupName, client.name, message.GetMessage())       // 执行异步回调
-						client.asyncReceiveCallbackDict.RemoveByKey(message.GetMessageId()) // 删除异步回调
-					}
-				} else { // 同步消息
-					client.receiveMessageChan <- message.GetMessage()
+			my.err = err
+			if my.onReceiveMessageFailCallback != nil {
+				my.onReceiveMessageFailCallback(my.groupName, my.name, conn, err)
+			}
+
+			if reconnect := my.reconnect.Load(); reconnect != nil {
+				my.reconnectLoop(reconnect)
+			} else {
+				my.setStatus(Offline)
+			}
+
+			return
+		}
+
+		switch messageType {
+		case websocket.TextMessage, websocket.BinaryMessage:
+			// 解析消息
+			message := ParseMessage(receiveMessage)
+			if my.onReceiveMessageSuccessCallback != nil {
+				my.onReceiveMessageSuccessCallback(my.groupName, my.name, message.GetMessage())
+			}
+			if message.GetAsync() { // 异步消息
+				if callback, ok := my.asyncReceiveCallbackDict.Get(message.GetMessageId()); ok {
+					callback(my.groupName, my.name, message.GetMessage())           // 执行异步回调
+					my.asyncReceiveCallbackDict.RemoveByKey(message.GetMessageId()) // 删除异步回调
 				}
-			case websocket.CloseMessage:
-				client.Close()
-			case websocket.PingMessage:
-				_ = my.conn.WriteMessage(websocket.TextMessage, []byte{})
-			case websocket.PongMessage:
+			} else { // 同步消息
+				my.receiveMessageChan <- message.GetMessage()
 			}
+		case websocket.CloseMessage:
+			my.Close()
+		case websocket.PingMessage:
+			_ = my.write(websocket.TextMessage, []byte{})
+		case websocket.PongMessage:
 		}
-	}(my)
+	}
+}
 
-	my.status = Online
+// write 写入消息：同一链接不支持并发写入；重连期间（直到缓冲的消息发送完毕）开启了缓冲时，消息进入缓冲，重连成功后按顺序发送，未开启缓冲时发送失败
+func (my *Client) write(messageType int, data []byte) error {
+	my.writeMu.Lock()
+	defer my.writeMu.Unlock()
 
-	return my
+	if my.GetStatus() == Reconnecting {
+		if reconnect := my.reconnect.Load(); reconnect != nil && reconnect.bufferSize > 0 {
+			return reconnect.push(messageType, data)
+		}
+
+		return WebsocketOfflineErr.New("重连中")
+	}
+
+	if my.conn == nil {
+		return WebsocketOfflineErr.New("")
+	}
+
+	return my.conn.WriteMessage(messageType, data)
 }
 
 // AsyncMsg 发送消息：异步
@@ -210,7 +295,7 @@ func (my *Client) AsyncMessage(message []byte, fn clientCallbackFn, timeout time
 		return my
 	}
 
-	my.err = my.conn.WriteMessage(websocket.TextMessage, msg.GetMessage()) // 发送消息
+	my.err = my.write(websocket.TextMessage, msg.GetMessage()) // 发送消息
 	if my.err != nil {
 		if my.onSendMessageFailCallback != nil {
 			my.onSendMessageFailCallback(my.groupName, my.name, my.conn, my.err) // 执行发送失败回调
@@ -224,8 +309,15 @@ func (my *Client) AsyncMessage(message []byte, fn clientCallbackFn, timeout time
 
 	go func(messageId string) {
 		<-timer // 超时删除异步回调方法
+
+		// 已收到回复
+		if !my.asyncReceiveCallbackDict.HasKey(messageId) {
+			return
+		}
 		my.asyncReceiveCallbackDict.RemoveByKey(messageId)
-		my.onSendMessageFailCallback(my.groupName, my.name, my.conn, AsyncMessageTimeoutErr.New("")) // 执行发送消息回调
+		if my.onSendMessageFailCallback != nil {
+			my.onSendMessageFailCallback(my.groupName, my.name, my.conn, AsyncMessageTimeoutErr.New("")) // 执行发送消息回调
+		}
 	}(msg.GetMessageId())
 
 	return my
@@ -244,7 +336,7 @@ func (my *Client) SyncMessage(message []byte, options ...any) ([]byte, error) {
 		msg     = NewMessage(false, message)
 	)
 
-	if my.conn == nil || my.status == Offline {
+	if my.GetStatus() == Offline {
 		if my.onSendMessageFailCallback != nil {
 			my.onSendMessageFailCallback(my.groupName, my.name, my.conn, WebsocketOfflineErr.New(""))
 		}
@@ -252,7 +344,7 @@ func (my *Client) SyncMessage(message []byte, options ...any) ([]byte, error) {
 		return nil, WebsocketOfflineErr.New("")
 	}
 
-	err = my.conn.WriteMessage(websocket.TextMessage, msg.GetMessage()) // 发送消息
+	err = my.write(websocket.TextMessage, msg.GetMessage()) // 发送消息
 	if err != nil {
 		if my.onSendMessageFailCallback != nil {
 			my.onSendMessageFailCallback(my.groupName, my.name, my.conn, err)
@@ -281,26 +373,52 @@ func (my *Client) SyncMessage(message []byte, options ...any) ([]byte, error) {
 	}
 }
 
+// SendEnvelope 发送消息信封：消息类型由编解码器决定
+func (my *Client) SendEnvelope(envelope *Envelope, codec EnvelopeCodec) error {
+	if my.GetStatus() == Offline {
+		return WebsocketOfflineErr.New("")
+	}
+
+	data, err := codec.Encode(envelope)
+	if err != nil {
+		return err
+	}
+
+	if err = my.write(codec.MessageType(), data); err != nil {
+		if my.onSendMessageFailCallback != nil {
+			my.onSendMessageFailCallback(my.groupName, my.name, my.conn, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 // Cls 关闭链接
 func (my *Client) Cls() *Client { return my.Close() }
 
 // Close 关闭链接
 func (my *Client) Close() *Client {
-	if my.conn != nil && my.status == Online {
+	my.closing.Store(true)
+	if reconnect := my.reconnect.Load(); reconnect != nil {
+		reconnect.stop()
+	}
+
+	if my.conn != nil && my.GetStatus() == Online {
 		my.err = my.conn.Close()
 		if my.err != nil {
 			if my.onCloseFailCallback != nil {
 				my.onCloseFailCallback(my.groupName, my.name, my.conn, my.err)
 			}
-			my.status = Online
 		} else {
 			my.conn = nil
-			my.status = Offline
+			my.setStatus(Offline)
 			close(my.receiveMessageChan) // 关闭同步消息通道
 		}
 	} else {
 		my.conn = nil
-		my.status = Offline
+		my.setStatus(Offline)
 		close(my.receiveMessageChan)
 	}
 
@@ -329,7 +447,7 @@ func (my *Client) Ping(fn pingFn) *Client {
 	if fn != nil {
 		my.err = fn(my.conn)
 	} else {
-		my.err = my.conn.WriteMessage(websocket.TextMessage, []byte(time.Now().String()))
+		my.err = my.write(websocket.TextMessage, []byte(time.Now().String()))
 	}
 
 	return my
@@ -0,0 +1,276 @@
+package websockets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jericho-yu/nova/src/util/operation"
+	"github.com/jericho-yu/nova/src/util/retry"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	// clientReconnect 自动重连配置与状态
+	clientReconnect struct {
+		sleep, maxSleep time.Duration // 由mu保护
+		attempts        int
+		hooks           []clientReconnectFn
+		bufferSize      int               // 由Client.writeMu保护
+		buffer          []bufferedMessage // 由Client.writeMu保护
+		running, again  bool
+		cancel          context.CancelFunc
+		mu              sync.Mutex
+	}
+
+	// bufferedMessage 重连期间缓冲的消息
+	bufferedMessage struct {
+		messageType int
+		data        []byte
+	}
+)
+
+// SetAutoReconnect 开启自动重连：链接断开后按指数退避（带随机抖动）重连，sleep为首次间隔，maxSleep为最大间隔（0表示不限制），attempts小于等于0表示不限次数
+func (my *Client) SetAutoReconnect(sleep, maxSleep time.Duration, attempts int) *Client {
+	if sleep <= 0 {
+		sleep = time.Second
+	}
+
+	my.reconnect.CompareAndSwap(nil, &clientReconnect{})
+	reconnect := my.reconnect.Load()
+
+	reconnect.mu.Lock()
+	defer reconnect.mu.Unlock()
+
+	reconnect.sleep, reconnect.maxSleep, reconnect.attempts = sleep, maxSleep, attempts
+
+	return my
+}
+
+// DisableAutoReconnect 关闭自动重连：正在进行的重连会被终止
+func (my *Client) DisableAutoReconnect() *Client {
+	if reconnect := my.reconnect.Swap(nil); reconnect != nil {
+		reconnect.stop()
+	}
+
+	return my
+}
+
+// SetReconnectBuffer 设置重连期间发送消息的缓冲数量：缓冲已满时发送失败，重连最终失败时缓冲的消息被丢弃，需先开启自动重连
+func (my *Client) SetReconnectBuffer(size int) *Client {
+	reconnect := my.reconnect.Load()
+	if reconnect == nil {
+		my.err = WebsocketConnOptionErr.New("未开启自动重连")
+		return my
+	}
+
+	my.writeMu.Lock()
+	defer my.writeMu.Unlock()
+
+	reconnect.bufferSize = size
+
+	return my
+}
+
+// AddOnReconnect 添加重连成功回调：用于重新订阅等，在发送缓冲消息之前按添加顺序执行，需先开启自动重连；
+// 回调执行时链接仍处于重连中，通过client发送的消息会进入缓冲，需要先于缓冲消息发送的内容应直接写入conn
+func (my *Client) AddOnReconnect(fn clientReconnectFn) *Client {
+	reconnect := my.reconnect.Load()
+	if reconnect == nil {
+		my.err = WebsocketConnOptionErr.New("未开启自动重连")
+		return my
+	}
+
+	if fn != nil {
+		reconnect.mu.Lock()
+		reconnect.hooks = append(reconnect.hooks, fn)
+		reconnect.mu.Unlock()
+	}
+
+	return my
+}
+
+// SetOnStatusChange 设置回调：链接状态变化（在线、离线、重连中）
+func (my *Client) SetOnStatusChange(fn clientStatusChangeFn) *Client {
+	my.onStatusChangeCallback = fn
+
+	return my
+}
+
+// reconnectLoop 重连：同一时间只有一个重连流程，重连过程中链接再次断开时，结束后重新执行
+func (my *Client) reconnectLoop(reconnect *clientReconnect) {
+	if !reconnect.start() {
+		return
+	}
+
+	for {
+		my.reconnectOnce(reconnect)
+		if !reconnect.finish() {
+			return
+		}
+	}
+}
+
+// reconnectOnce 执行一次重连流程
+func (my *Client) reconnectOnce(reconnect *clientReconnect) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reconnect.setCancel(cancel)
+
+	my.setStatus(Reconnecting)
+
+	var (
+		conn                             *websocket.Conn
+		sleep, maxSleep, attempts, hooks = reconnect.config()
+	)
+	err := retry.RetryApp.New().
+		SetMaxSleep(maxSleep).
+		SetSleep(sleep).
+		SetCtx(ctx).
+		SetFn(func() (err error) {
+			if my.closing.Load() {
+				cancel()
+				return context.Canceled
+			}
+			conn, err = my.dial()
+			return
+		}).
+		WithContextAndJitter(operation.Ternary(attempts > 0, attempts, math.MaxInt))
+
+	if my.closing.Load() { // 重连期间被主动关闭
+		if err == nil && conn != nil {
+			_ = conn.Close()
+		}
+		my.dropBuffer(reconnect)
+		return
+	}
+
+	if err != nil {
+		my.err = err
+		my.setStatus(Offline)
+		my.dropBuffer(reconnect)
+		return
+	}
+
+	go my.listen(conn)
+
+	// 重连中的状态保持到缓冲消息发送完毕，期间其他发送进入缓冲，只有回调直接写入链接
+	for _, hook := range hooks {
+		if err = hook(my, conn); err != nil && my.onConnFailCallback != nil {
+			my.onConnFailCallback(my.groupName, my.name, conn, err)
+		}
+	}
+
+	my.flushBuffer(reconnect, conn)
+}
+
+// flushBuffer 按顺序发送缓冲的消息，全部发送后标记为在线：发送失败时保留剩余消息，等待下次重连
+func (my *Client) flushBuffer(reconnect *clientReconnect, conn *websocket.Conn) {
+	my.writeMu.Lock()
+
+	if my.closing.Load() { // 回调执行期间被主动关闭
+		my.writeMu.Unlock()
+		my.dropBuffer(reconnect)
+		return
+	}
+
+	for len(reconnect.buffer) > 0 {
+		message := reconnect.buffer[0]
+		if err := conn.WriteMessage(message.messageType, message.data); err != nil {
+			my.writeMu.Unlock()
+			if my.onSendMessageFailCallback != nil {
+				my.onSendMessageFailCallback(my.groupName, my.name, conn, err)
+			}
+			return
+		}
+		reconnect.buffer = reconnect.buffer[1:]
+	}
+
+	from := my.swapStatus(Online)
+	my.writeMu.Unlock()
+
+	my.notifyStatus(from, Online)
+}
+
+// dropBuffer 丢弃缓冲的消息
+func (my *Client) dropBuffer(reconnect *clientReconnect) {
+	my.writeMu.Lock()
+	count := len(reconnect.buffer)
+	reconnect.buffer = nil
+	my.writeMu.Unlock()
+
+	if count > 0 && my.onSendMessageFailCallback != nil {
+		my.onSendMessageFailCallback(my.groupName, my.name, nil, WebsocketOfflineErr.New(fmt.Sprintf("重连失败，丢弃%d条缓冲消息", count)))
+	}
+}
+
+// push 缓冲消息：调用方需持有Client.writeMu
+func (my *clientReconnect) push(messageType int, data []byte) error {
+	if len(my.buffer) >= my.bufferSize {
+		return ReconnectBufferFullErr.New(fmt.Sprintf("%d", my.bufferSize))
+	}
+
+	my.buffer = append(my.buffer, bufferedMessage{messageType: messageType, data: bytes.Clone(data)})
+
+	return nil
+}
+
+// config 获取重连配置
+func (my *clientReconnect) config() (sleep, maxSleep time.Duration, attempts int, hooks []clientReconnectFn) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	return my.sleep, my.maxSleep, my.attempts, slices.Clone(my.hooks)
+}
+
+// start 标记开始重连：已在重连时标记需要再次执行并返回false
+func (my *clientReconnect) start() bool {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if my.running {
+		my.again = true
+		return false
+	}
+	my.running, my.again = true, false
+
+	return true
+}
+
+// finish 标记结束重连：期间链接再次断开时返回true
+func (my *clientReconnect) finish() bool {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if my.again {
+		my.again = false
+		return true
+	}
+	my.running, my.cancel = false, nil
+
+	return false
+}
+
+// setCancel 保存取消方法
+func (my *clientReconnect) setCancel(cancel context.CancelFunc) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.cancel = cancel
+}
+
+// stop 终止正在进行的重连
+func (my *clientReconnect) stop() {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.again = false
+	if my.cancel != nil {
+		my.cancel()
+	}
+}
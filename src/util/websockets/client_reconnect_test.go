@@ -0,0 +1,257 @@
+package websockets
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestEchoServer 启动可在原地址重启的本地websocket服务：收到的消息体写入received，stop用于模拟服务端宕机
+func newTestEchoServer(t *testing.T, addr string, received chan<- string) (server *httptest.Server, stop func()) {
+	t.Helper()
+
+	var (
+		conns []*websocket.Conn
+		mu    sync.Mutex
+	)
+
+	server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		conns = append(conns, conn)
+		mu.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			message := ParseMessage(data)
+			received <- string(message.GetMessage())
+		}
+	}))
+
+	if addr != "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatalf("监听失败：%v", err)
+		}
+		server.Listener = listener
+	}
+	server.Start()
+
+	stop = func() {
+		mu.Lock()
+		for _, conn := range conns {
+			_ = conn.Close() // 升级后的链接不受httptest管理，需手动关闭
+		}
+		mu.Unlock()
+		server.Close()
+	}
+	t.Cleanup(stop)
+
+	return server, stop
+}
+
+// receive 读取服务端收到的消息
+func receive(t *testing.T, received <-chan string) string {
+	t.Helper()
+
+	select {
+	case message := <-received:
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待消息超时")
+		return ""
+	}
+}
+
+// TestReconnect1AutoReconnect 测试：自动重连
+func TestReconnect1AutoReconnect(t *testing.T) {
+	var (
+		received     = make(chan string, 10)
+		server, stop = newTestEchoServer(t, "", received)
+		addr         = server.Listener.Addr().String()
+		statuses     []string
+		hookStatus   WebsocketConnStatus
+		mu           sync.Mutex
+	)
+
+	client, err := ClientApp.New("groupA", "reconnect", "ws://"+addr, ClientCallbackConfig{})
+	if err != nil {
+		t.Fatalf("创建客户端失败：%v", err)
+	}
+
+	client.
+		SetOnStatusChange(func(groupName, name string, from, to WebsocketConnStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			statuses = append(statuses, string(from)+">"+string(to))
+		}).
+		SetAutoReconnect(20*time.Millisecond, 100*time.Millisecond, 0).
+		SetReconnectBuffer(2).
+		AddOnReconnect(func(client *Client, conn *websocket.Conn) error {
+			mu.Lock()
+			hookStatus = client.GetStatus()
+			mu.Unlock()
+
+			data, err := EnvelopeApp.New(EnvelopeEvent, []byte("subscribe")).Encode(JsonEnvelopeCodec)
+			if err != nil {
+				return err
+			}
+			return conn.WriteMessage(JsonEnvelopeCodec.MessageType(), data)
+		})
+	if err = client.Boot().Error(); err != nil {
+		t.Fatalf("启动失败：%v", err)
+	}
+	defer client.Close()
+
+	t.Run("在线发送", func(t *testing.T) {
+		if err = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte("hello")), BinaryEnvelopeCodec); err != nil {
+			t.Fatalf("发送失败：%v", err)
+		}
+		if message := receive(t, received); message != "hello" {
+			t.Errorf("消息错误：%s", message)
+		}
+	})
+
+	t.Run("断线期间缓冲消息", func(t *testing.T) {
+		stop()
+		waitFor(t, func() bool { return client.GetStatus() == Reconnecting })
+
+		for _, message := range []string{"buffer-1", "buffer-2"} {
+			if err = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte(message)), JsonEnvelopeCodec); err != nil {
+				t.Fatalf("缓冲失败：%v", err)
+			}
+		}
+		if err = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte("buffer-3")), JsonEnvelopeCodec); err == nil || !strings.Contains(err.Error(), "重连缓冲已满") {
+			t.Errorf("期望缓冲已满：%v", err)
+		}
+	})
+
+	t.Run("重连后重新订阅并发送缓冲消息", func(t *testing.T) {
+		newTestEchoServer(t, addr, received)
+
+		waitFor(t, func() bool { return client.GetStatus() == Online })
+		for _, expect := range []string{"subscribe", "buffer-1", "buffer-2"} {
+			if message := receive(t, received); message != expect {
+				t.Errorf("消息顺序错误：期望%s，实际%s", expect, message)
+			}
+		}
+	})
+
+	t.Run("状态回调", func(t *testing.T) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		expect := []string{"OFF-LINE>ON-LINE", "ON-LINE>RECONNECTING", "RECONNECTING>ON-LINE"}
+		if !slices.Equal(statuses, expect) {
+			t.Errorf("状态变化错误：%v", statuses)
+		}
+		if hookStatus != Reconnecting {
+			t.Errorf("回调执行时应处于重连中：%s", hookStatus)
+		}
+	})
+}
+
+// TestReconnect2GiveUp 测试：重连次数用尽
+func TestReconnect2GiveUp(t *testing.T) {
+	var (
+		received     = make(chan string, 10)
+		server, stop = newTestEchoServer(t, "", received)
+		dropped      = make(chan error, 1)
+	)
+
+	client, err := ClientApp.New("groupA", "give-up", "ws://"+server.Listener.Addr().String(), ClientCallbackConfig{
+		OnSendMessageFailCallback: func(groupName, name string, conn *websocket.Conn, err error) { dropped <- err },
+	})
+	if err != nil {
+		t.Fatalf("创建客户端失败：%v", err)
+	}
+
+	client.SetAutoReconnect(50*time.Millisecond, 100*time.Millisecond, 3).SetReconnectBuffer(5).Boot()
+	defer client.Close()
+
+	stop()
+	waitFor(t, func() bool { return client.GetStatus() == Reconnecting })
+
+	if err = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte("lost")), JsonEnvelopeCodec); err != nil {
+		t.Fatalf("缓冲失败：%v", err)
+	}
+
+	waitFor(t, func() bool { return client.GetStatus() == Offline })
+	select {
+	case err = <-dropped:
+		if !strings.Contains(err.Error(), "丢弃1条缓冲消息") {
+			t.Errorf("丢弃消息错误：%v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("未通知丢弃的缓冲消息")
+	}
+}
+
+// TestReconnect3Offline 测试：未开启或关闭自动重连时断线
+func TestReconnect3Offline(t *testing.T) {
+	t.Run("未开启自动重连时标记为离线", func(t *testing.T) {
+		var (
+			received     = make(chan string, 10)
+			server, stop = newTestEchoServer(t, "", received)
+		)
+
+		client, err := ClientApp.New("groupA", "offline", "ws://"+server.Listener.Addr().String(), ClientCallbackConfig{})
+		if err != nil {
+			t.Fatalf("创建客户端失败：%v", err)
+		}
+		if client.Boot().GetStatus() != Online {
+			t.Fatal("启动失败")
+		}
+		defer client.Close()
+
+		stop()
+		waitFor(t, func() bool { return client.GetStatus() == Offline })
+	})
+
+	t.Run("重连中关闭自动重连", func(t *testing.T) {
+		var (
+			received     = make(chan string, 10)
+			server, stop = newTestEchoServer(t, "", received)
+		)
+
+		client, err := ClientApp.New("groupA", "disable", "ws://"+server.Listener.Addr().String(), ClientCallbackConfig{})
+		if err != nil {
+			t.Fatalf("创建客户端失败：%v", err)
+		}
+		client.SetAutoReconnect(20*time.Millisecond, 50*time.Millisecond, 0).SetReconnectBuffer(2).Boot()
+		defer client.Close()
+
+		stop()
+		waitFor(t, func() bool { return client.GetStatus() == Reconnecting })
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 20 {
+				_ = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte("racing")), JsonEnvelopeCodec)
+			}
+		}()
+		client.DisableAutoReconnect()
+		<-done
+
+		waitFor(t, func() bool { return client.GetStatus() == Offline })
+		if err = client.SendEnvelope(EnvelopeApp.New(EnvelopeEvent, []byte("after")), JsonEnvelopeCodec); err == nil {
+			t.Error("关闭自动重连后期望发送失败")
+		}
+	})
+}
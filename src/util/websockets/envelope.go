@@ -0,0 +1,308 @@
+package websockets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+type (
+	// EnvelopeType 信封类型
+	EnvelopeType uint8
+
+	// Envelope 消息信封：带版本号，包含消息编号、类型、回复编号、消息头与消息体，消息体可以是任意二进制
+	Envelope struct {
+		Version uint8             `json:"_v"`
+		Id      string            `json:"id,omitempty"`
+		Type    EnvelopeType      `json:"type"`
+		ReplyTo string            `json:"replyTo,omitempty"`
+		Headers map[string]string `json:"headers,omitempty"`
+		Payload []byte            `json:"payload,omitempty"`
+	}
+
+	// EnvelopeCodec 信封编解码器
+	EnvelopeCodec interface {
+		Encode(envelope *Envelope) ([]byte, error)
+		Decode(data []byte) (*Envelope, error)
+		// MessageType 发送时使用的websocket消息类型
+		MessageType() int
+	}
+
+	jsonEnvelopeCodec   struct{}
+	binaryEnvelopeCodec struct{}
+
+	// envelopeReader 二进制信封读取器：越界时记录错误
+	envelopeReader struct {
+		data   []byte
+		offset int
+		err    error
+	}
+)
+
+const (
+	// EnvelopeVersion 当前信封版本，0表示由旧格式（uuid:payload）解析而来
+	EnvelopeVersion uint8 = 1
+)
+
+const (
+	EnvelopeEvent   EnvelopeType = iota + 1 // 事件（单向消息）
+	EnvelopeRequest                         // 请求
+	EnvelopeReply                           // 回复
+	EnvelopeError                           // 错误回复
+)
+
+var (
+	EnvelopeApp Envelope
+
+	// JsonEnvelopeCodec json编解码器：消息体以base64编码，使用文本消息发送
+	JsonEnvelopeCodec EnvelopeCodec = jsonEnvelopeCodec{}
+	// BinaryEnvelopeCodec 二进制编解码器：长度前缀格式，使用二进制消息发送
+	//
+	// 格式（大端）：magic(3) version(1) type(1) id(u16长度+内容) replyTo(u16长度+内容)
+	// headers(u16数量，每项key(u16长度+内容) value(u32长度+内容)) payload(u32长度+内容)
+	BinaryEnvelopeCodec EnvelopeCodec = binaryEnvelopeCodec{}
+
+	binaryEnvelopeMagic = []byte{0x00, 'N', 'V'}
+	envelopeTypeNames   = map[EnvelopeType]string{EnvelopeEvent: "event", EnvelopeRequest: "request", EnvelopeReply: "reply", EnvelopeError: "error"}
+)
+
+// String 类型名称
+func (my EnvelopeType) String() string {
+	if name, ok := envelopeTypeNames[my]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(my))
+}
+
+// New 实例化：消息信封，自动生成消息编号
+func (*Envelope) New(envelopeType EnvelopeType, payload []byte) *Envelope {
+	return &Envelope{
+		Version: EnvelopeVersion,
+		Id:      uuid.Must(uuid.NewV6()).String(),
+		Type:    envelopeType,
+		Headers: map[string]string{},
+		Payload: payload,
+	}
+}
+
+// Parse 解析消息信封：自动识别二进制、json及旧格式（uuid:payload），无法识别时作为无编号的事件
+func (*Envelope) Parse(data []byte) *Envelope {
+	message := ParseMessage(data)
+
+	return message.GetEnvelope()
+}
+
+// SetReplyTo 设置回复的消息编号
+func (my *Envelope) SetReplyTo(replyTo string) *Envelope {
+	my.ReplyTo = replyTo
+
+	return my
+}
+
+// SetHeader 设置消息头
+func (my *Envelope) SetHeader(key, value string) *Envelope {
+	if my.Headers == nil {
+		my.Headers = map[string]string{}
+	}
+	my.Headers[key] = value
+
+	return my
+}
+
+// GetHeader 获取消息头
+func (my *Envelope) GetHeader(key string) string { return my.Headers[key] }
+
+// Reply 创建回复信封
+func (my *Envelope) Reply(payload []byte) *Envelope {
+	return EnvelopeApp.New(EnvelopeReply, payload).SetReplyTo(my.Id)
+}
+
+// ReplyError 创建错误回复信封
+func (my *Envelope) ReplyError(err error) *Envelope {
+	return EnvelopeApp.New(EnvelopeError, []byte(err.Error())).SetReplyTo(my.Id)
+}
+
+// Encode 编码
+func (my *Envelope) Encode(codec EnvelopeCodec) ([]byte, error) { return codec.Encode(my) }
+
+// Encode 编码：json
+func (jsonEnvelopeCodec) Encode(envelope *Envelope) ([]byte, error) {
+	clone := *envelope
+	if clone.Version == 0 {
+		clone.Version = EnvelopeVersion
+	}
+
+	data, err := json.Marshal(clone)
+	if err != nil {
+		return nil, EnvelopeEncodeErr.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// Decode 解码：json
+func (jsonEnvelopeCodec) Decode(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, EnvelopeDecodeErr.Wrap(err)
+	}
+
+	if envelope.Version == 0 {
+		return nil, EnvelopeDecodeErr.New("缺少版本号")
+	}
+	if envelope.Version > EnvelopeVersion {
+		return nil, EnvelopeDecodeErr.New(fmt.Sprintf("不支持的版本：%d", envelope.Version))
+	}
+	if envelope.Headers == nil {
+		envelope.Headers = map[string]string{}
+	}
+
+	return &envelope, nil
+}
+
+// MessageType 文本消息
+func (jsonEnvelopeCodec) MessageType() int { return websocket.TextMessage }
+
+// Encode 编码：二进制
+func (binaryEnvelopeCodec) Encode(envelope *Envelope) ([]byte, error) {
+	if len(envelope.Id) > math.MaxUint16 || len(envelope.ReplyTo) > math.MaxUint16 || len(envelope.Headers) > math.MaxUint16 {
+		return nil, EnvelopeEncodeErr.New("消息编号、回复编号或消息头数量超出限制")
+	}
+	if uint64(len(envelope.Payload)) > math.MaxUint32 {
+		return nil, EnvelopeEncodeErr.New("消息体超出限制")
+	}
+
+	buf := bytes.Buffer{}
+	buf.Write(binaryEnvelopeMagic)
+	buf.WriteByte(EnvelopeVersion)
+	buf.WriteByte(byte(envelope.Type))
+	writeUint16String(&buf, envelope.Id)
+	writeUint16String(&buf, envelope.ReplyTo)
+
+	buf.Write(binary.BigEndian.AppendUint16(nil, uint16(len(envelope.Headers))))
+	for _, key := range slices.Sorted(maps.Keys(envelope.Headers)) {
+		if len(key) > math.MaxUint16 || uint64(len(envelope.Headers[key])) > math.MaxUint32 {
+			return nil, EnvelopeEncodeErr.New("消息头超出限制：" + key)
+		}
+		writeUint16String(&buf, key)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(envelope.Headers[key]))))
+		buf.WriteString(envelope.Headers[key])
+	}
+
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(envelope.Payload))))
+	buf.Write(envelope.Payload)
+
+	return buf.Bytes(), nil
+}
+
+// Decode 解码：二进制
+func (binaryEnvelopeCodec) Decode(data []byte) (*Envelope, error) {
+	if !bytes.HasPrefix(data, binaryEnvelopeMagic) {
+		return nil, EnvelopeDecodeErr.New("不是二进制信封")
+	}
+
+	var (
+		reader   = &envelopeReader{data: data, offset: len(binaryEnvelopeMagic)}
+		envelope = &Envelope{Version: reader.uint8()}
+	)
+
+	if envelope.Version == 0 || envelope.Version > EnvelopeVersion {
+		return nil, EnvelopeDecodeErr.New(fmt.Sprintf("不支持的版本：%d", envelope.Version))
+	}
+
+	envelope.Type = EnvelopeType(reader.uint8())
+	envelope.Id = string(reader.next(int(reader.uint16())))
+	envelope.ReplyTo = string(reader.next(int(reader.uint16())))
+
+	count := int(reader.uint16())
+	envelope.Headers = make(map[string]string, count)
+	for range count {
+		key := string(reader.next(int(reader.uint16())))
+		envelope.Headers[key] = string(reader.next(int(reader.uint32())))
+	}
+
+	if payload := reader.next(int(reader.uint32())); len(payload) > 0 {
+		envelope.Payload = bytes.Clone(payload)
+	}
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if reader.offset != len(data) {
+		return nil, EnvelopeDecodeErr.New(fmt.Sprintf("存在多余的%d字节", len(data)-reader.offset))
+	}
+
+	return envelope, nil
+}
+
+// MessageType 二进制消息
+func (binaryEnvelopeCodec) MessageType() int { return websocket.BinaryMessage }
+
+// writeUint16String 写入u16长度前缀的字符串
+func writeUint16String(buf *bytes.Buffer, value string) {
+	buf.Write(binary.BigEndian.AppendUint16(nil, uint16(len(value))))
+	buf.WriteString(value)
+}
+
+// next 读取n个字节
+func (my *envelopeReader) next(n int) []byte {
+	if my.err != nil {
+		return nil
+	}
+	if n < 0 || my.offset+n > len(my.data) {
+		my.err = EnvelopeDecodeErr.New("数据长度不足")
+		return nil
+	}
+
+	data := my.data[my.offset : my.offset+n]
+	my.offset += n
+
+	return data
+}
+
+func (my *envelopeReader) uint8() uint8 {
+	if data := my.next(1); data != nil {
+		return data[0]
+	}
+
+	return 0
+}
+
+func (my *envelopeReader) uint16() uint16 {
+	if data := my.next(2); data != nil {
+		return binary.BigEndian.Uint16(data)
+	}
+
+	return 0
+}
+
+func (my *envelopeReader) uint32() uint32 {
+	if data := my.next(4); data != nil {
+		return binary.BigEndian.Uint32(data)
+	}
+
+	return 0
+}
+
+// decodeEnvelope 尝试按二进制或json信封解码
+func decodeEnvelope(data []byte) (*Envelope, bool) {
+	if bytes.HasPrefix(data, binaryEnvelopeMagic) {
+		envelope, err := BinaryEnvelopeCodec.Decode(data)
+		return envelope, err == nil
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' && bytes.Contains(trimmed, []byte(`"_v"`)) {
+		envelope, err := JsonEnvelopeCodec.Decode(trimmed)
+		return envelope, err == nil
+	}
+
+	return nil, false
+}
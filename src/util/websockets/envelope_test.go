@@ -0,0 +1,102 @@
+package websockets
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// TestEnvelope1Codec 测试：信封编解码
+func TestEnvelope1Codec(t *testing.T) {
+	envelope := EnvelopeApp.New(EnvelopeRequest, []byte{0x00, ':', 0xff, ':'}).
+		SetReplyTo("abc").
+		SetHeader("method", "user.get").
+		SetHeader("token", "a:b")
+
+	for name, codec := range map[string]EnvelopeCodec{"json": JsonEnvelopeCodec, "二进制": BinaryEnvelopeCodec} {
+		t.Run(name, func(t *testing.T) {
+			data, err := envelope.Encode(codec)
+			if err != nil {
+				t.Fatalf("编码失败：%v", err)
+			}
+
+			decoded, err := codec.Decode(data)
+			if err != nil {
+				t.Fatalf("解码失败：%v", err)
+			}
+
+			if decoded.Version != EnvelopeVersion || decoded.Id != envelope.Id || decoded.Type != EnvelopeRequest || decoded.ReplyTo != "abc" {
+				t.Errorf("信封不一致：%+v", decoded)
+			}
+			if decoded.GetHeader("method") != "user.get" || decoded.GetHeader("token") != "a:b" {
+				t.Errorf("消息头不一致：%v", decoded.Headers)
+			}
+			if !bytes.Equal(decoded.Payload, envelope.Payload) {
+				t.Errorf("消息体不一致：%v", decoded.Payload)
+			}
+		})
+	}
+
+	t.Run("二进制数据不完整", func(t *testing.T) {
+		data, _ := envelope.Encode(BinaryEnvelopeCodec)
+		for _, broken := range [][]byte{data[:len(data)-1], append(bytes.Clone(data), 0x01)} {
+			if _, err := BinaryEnvelopeCodec.Decode(broken); !errors.Is(err, &EnvelopeDecodeErr) {
+				t.Errorf("期望解码错误：%v", err)
+			}
+		}
+	})
+
+	t.Run("不支持的版本", func(t *testing.T) {
+		if _, err := JsonEnvelopeCodec.Decode([]byte(`{"_v":99,"type":1}`)); !errors.Is(err, &EnvelopeDecodeErr) {
+			t.Errorf("期望解码错误：%v", err)
+		}
+	})
+}
+
+// TestEnvelope2ParseMessage 测试：兼容解析
+func TestEnvelope2ParseMessage(t *testing.T) {
+	t.Run("旧格式异步消息：消息体包含冒号", func(t *testing.T) {
+		id := uuid.Must(uuid.NewV6()).String()
+		message := ParseMessage([]byte(id + ":a:b:c"))
+		if !message.GetAsync() || message.GetMessageId() != id || string(message.GetMessage()) != "a:b:c" {
+			t.Errorf("解析错误：%v %s %s", message.GetAsync(), message.GetMessageId(), message.GetMessage())
+		}
+	})
+
+	t.Run("旧格式同步消息：冒号前不是uuid", func(t *testing.T) {
+		message := ParseMessage([]byte("time:12:00"))
+		if message.GetAsync() || string(message.GetMessage()) != "time:12:00" {
+			t.Errorf("解析错误：%v %s", message.GetAsync(), message.GetMessage())
+		}
+	})
+
+	t.Run("新建消息与解析一致", func(t *testing.T) {
+		sent := NewMessage(true, []byte("x:y"))
+		received := ParseMessage(sent.GetMessage())
+		if received.GetMessageId() != sent.GetMessageId() || string(received.GetMessage()) != "x:y" {
+			t.Errorf("解析错误：%s %s", received.GetMessageId(), received.GetMessage())
+		}
+	})
+
+	t.Run("普通json消息不视为信封", func(t *testing.T) {
+		message := ParseMessage([]byte(`{"target":"a"}`))
+		if message.GetAsync() || message.GetEnvelope().Version != 0 || string(message.GetMessage()) != `{"target":"a"}` {
+			t.Errorf("解析错误：%s", message.GetMessage())
+		}
+	})
+
+	t.Run("回复信封使用回复编号", func(t *testing.T) {
+		request := EnvelopeApp.New(EnvelopeRequest, []byte("ping"))
+		data, _ := request.Reply([]byte("pong")).Encode(BinaryEnvelopeCodec)
+
+		message := ParseMessage(data)
+		if !message.GetAsync() || message.GetMessageId() != request.Id || string(message.GetMessage()) != "pong" {
+			t.Errorf("解析错误：%s %s", message.GetMessageId(), message.GetMessage())
+		}
+		if message.GetEnvelope().Type != EnvelopeReply {
+			t.Errorf("类型错误：%s", message.GetEnvelope().Type)
+		}
+	})
+}
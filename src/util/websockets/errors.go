@@ -22,6 +22,13 @@ type (
 	WebsocketServerConnTagEmpty                         struct{ myError.MyError }
 	WebsocketServerConnTagExist                         struct{ myError.MyError }
 	WebsocketServerOnReceiveMessageSuccessCallbackEmpty struct{ myError.MyError }
+	EnvelopeEncode                                      struct{ myError.MyError }
+	EnvelopeDecode                                      struct{ myError.MyError }
+	RpcMethodNotFound                                   struct{ myError.MyError }
+	RpcUnauthorized                                     struct{ myError.MyError }
+	RpcPanic                                            struct{ myError.MyError }
+	ReconnectBufferFull                                 struct{ myError.MyError }
+	WebsocketBroker                                     struct{ myError.MyError }
 )
 
 var (
@@ -36,6 +43,13 @@ var (
 	WebsocketServerConnTagEmptyErr                         WebsocketServerConnTagEmpty
 	WebsocketServerConnTagExistErr                         WebsocketServerConnTagExist
 	WebsocketServerOnReceiveMessageSuccessCallbackEmptyErr WebsocketServerOnReceiveMessageSuccessCallbackEmpty
+	EnvelopeEncodeErr                                      EnvelopeEncode
+	EnvelopeDecodeErr                                      EnvelopeDecode
+	RpcMethodNotFoundErr                                   RpcMethodNotFound
+	RpcUnauthorizedErr                                     RpcUnauthorized
+	RpcPanicErr                                            RpcPanic
+	ReconnectBufferFullErr                                 ReconnectBufferFull
+	WebsocketBrokerErr                                     WebsocketBroker
 )
 
 func (*WebsocketConnOption) New(msg string) myError.IMyError {
@@ -235,3 +249,129 @@ func (my *WebsocketServerOnReceiveMessageSuccessCallbackEmpty) Error() string {
 func (*WebsocketServerOnReceiveMessageSuccessCallbackEmpty) Is(target error) bool {
 	return reflect.DeepEqual(target, &WebsocketServerOnReceiveMessageSuccessCallbackEmptyErr)
 }
+
+func (*EnvelopeEncode) New(msg string) myError.IMyError {
+	return &EnvelopeEncode{myError.MyError{Msg: array.NewDestruction("消息编码失败", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*EnvelopeEncode) Wrap(err error) myError.IMyError {
+	return &EnvelopeEncode{myError.MyError{Msg: fmt.Errorf("消息编码失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*EnvelopeEncode) Panic() myError.IMyError {
+	return &EnvelopeEncode{myError.MyError{Msg: "消息编码失败"}}
+}
+
+func (my *EnvelopeEncode) Error() string { return my.Msg }
+
+func (*EnvelopeEncode) Is(target error) bool {
+	return reflect.DeepEqual(target, &EnvelopeEncodeErr)
+}
+
+func (*EnvelopeDecode) New(msg string) myError.IMyError {
+	return &EnvelopeDecode{myError.MyError{Msg: array.NewDestruction("消息解码失败", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*EnvelopeDecode) Wrap(err error) myError.IMyError {
+	return &EnvelopeDecode{myError.MyError{Msg: fmt.Errorf("消息解码失败"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*EnvelopeDecode) Panic() myError.IMyError {
+	return &EnvelopeDecode{myError.MyError{Msg: "消息解码失败"}}
+}
+
+func (my *EnvelopeDecode) Error() string { return my.Msg }
+
+func (*EnvelopeDecode) Is(target error) bool {
+	return reflect.DeepEqual(target, &EnvelopeDecodeErr)
+}
+
+func (*RpcMethodNotFound) New(msg string) myError.IMyError {
+	return &RpcMethodNotFound{myError.MyError{Msg: array.NewDestruction("rpc方法不存在", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*RpcMethodNotFound) Wrap(err error) myError.IMyError {
+	return &RpcMethodNotFound{myError.MyError{Msg: fmt.Errorf("rpc方法不存在"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RpcMethodNotFound) Panic() myError.IMyError {
+	return &RpcMethodNotFound{myError.MyError{Msg: "rpc方法不存在"}}
+}
+
+func (my *RpcMethodNotFound) Error() string { return my.Msg }
+
+func (*RpcMethodNotFound) Is(target error) bool {
+	return reflect.DeepEqual(target, &RpcMethodNotFoundErr)
+}
+
+func (*RpcUnauthorized) New(msg string) myError.IMyError {
+	return &RpcUnauthorized{myError.MyError{Msg: array.NewDestruction("rpc未授权", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*RpcUnauthorized) Wrap(err error) myError.IMyError {
+	return &RpcUnauthorized{myError.MyError{Msg: fmt.Errorf("rpc未授权"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RpcUnauthorized) Panic() myError.IMyError {
+	return &RpcUnauthorized{myError.MyError{Msg: "rpc未授权"}}
+}
+
+func (my *RpcUnauthorized) Error() string { return my.Msg }
+
+func (*RpcUnauthorized) Is(target error) bool {
+	return reflect.DeepEqual(target, &RpcUnauthorizedErr)
+}
+
+func (*RpcPanic) New(msg string) myError.IMyError {
+	return &RpcPanic{myError.MyError{Msg: array.NewDestruction("rpc处理异常", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*RpcPanic) Wrap(err error) myError.IMyError {
+	return &RpcPanic{myError.MyError{Msg: fmt.Errorf("rpc处理异常"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*RpcPanic) Panic() myError.IMyError {
+	return &RpcPanic{myError.MyError{Msg: "rpc处理异常"}}
+}
+
+func (my *RpcPanic) Error() string { return my.Msg }
+
+func (*RpcPanic) Is(target error) bool {
+	return reflect.DeepEqual(target, &RpcPanicErr)
+}
+
+func (*ReconnectBufferFull) New(msg string) myError.IMyError {
+	return &ReconnectBufferFull{myError.MyError{Msg: array.NewDestruction("重连缓冲已满", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*ReconnectBufferFull) Wrap(err error) myError.IMyError {
+	return &ReconnectBufferFull{myError.MyError{Msg: fmt.Errorf("重连缓冲已满"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*ReconnectBufferFull) Panic() myError.IMyError {
+	return &ReconnectBufferFull{myError.MyError{Msg: "重连缓冲已满"}}
+}
+
+func (my *ReconnectBufferFull) Error() string { return my.Msg }
+
+func (*ReconnectBufferFull) Is(target error) bool {
+	return reflect.DeepEqual(target, &ReconnectBufferFullErr)
+}
+
+func (*WebsocketBroker) New(msg string) myError.IMyError {
+	return &WebsocketBroker{myError.MyError{Msg: array.NewDestruction("消息代理错误", msg).JoinWithoutEmpty("：")}}
+}
+
+func (*WebsocketBroker) Wrap(err error) myError.IMyError {
+	return &WebsocketBroker{myError.MyError{Msg: fmt.Errorf("消息代理错误"+operation.Ternary(err != nil, "：%w", "%w"), err).Error()}}
+}
+
+func (*WebsocketBroker) Panic() myError.IMyError {
+	return &WebsocketBroker{myError.MyError{Msg: "消息代理错误"}}
+}
+
+func (my *WebsocketBroker) Error() string { return my.Msg }
+
+func (*WebsocketBroker) Is(target error) bool {
+	return reflect.DeepEqual(target, &WebsocketBrokerErr)
+}
@@ -12,6 +12,8 @@ type (
 	clientStandardFailFn          func(groupName, name string, conn *websocket.Conn, err error)
 	clientReceiveMessageSuccessFn func(groupName, name string, prototypeMessage []byte)
 	clientHeartFn                 func(groupName, name string, client *Client)
+	clientStatusChangeFn          func(groupName, name string, from, to WebsocketConnStatus)
+	clientReconnectFn             func(client *Client, conn *websocket.Conn) error
 	pingFn                        func(conn *websocket.Conn) error
 	serverConnectionFailFn        func(err error)
 	serverConnectionSuccessFn     func(conn *websocket.Conn) error
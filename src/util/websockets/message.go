@@ -2,7 +2,6 @@ package websockets
 
 import (
 	"bytes"
-	"strings"
 
 	"github.com/jericho-yu/nova/src/util/operation"
 
@@ -14,6 +13,7 @@ type Message struct {
 	messageId        string
 	message          []byte
 	prototypeMessage []byte
+	envelope         *Envelope
 }
 
 var MessageApp Message
@@ -41,23 +41,33 @@ func NewMessage(async bool, message []byte) Message {
 	}
 }
 
-// ParseMessage 解析消息
+// ParseMessage 解析消息：兼容信封格式（二进制或json）与旧格式（uuid:payload）
+//
+// 信封格式中回复类消息的消息编号取回复编号，以便与发送方的异步回调对应
 //
 //go:fix 推荐使用：推荐使用Parse方法
 func ParseMessage(prototypeMessage []byte) Message {
-	var (
-		messages = strings.Split(string(prototypeMessage), ":")
-		wm       = Message{}
-	)
-
-	if len(messages) == 2 {
-		wm.messageId = messages[0]
-		wm.message = []byte(messages[1])
-		wm.prototypeMessage = prototypeMessage
-		wm.async = true
-	} else {
-		wm.message = prototypeMessage
-		wm.prototypeMessage = prototypeMessage
+	if envelope, ok := decodeEnvelope(prototypeMessage); ok {
+		messageId := operation.Ternary(envelope.ReplyTo != "", envelope.ReplyTo, envelope.Id)
+
+		return Message{
+			async:            messageId != "",
+			messageId:        messageId,
+			message:          envelope.Payload,
+			prototypeMessage: prototypeMessage,
+			envelope:         envelope,
+		}
+	}
+
+	wm := Message{message: prototypeMessage, prototypeMessage: prototypeMessage}
+
+	// 旧格式：仅在冒号前为合法uuid时视为异步消息，消息体中可以包含冒号
+	if messageId, message, found := bytes.Cut(prototypeMessage, []byte{':'}); found && len(messageId) == 36 {
+		if _, err := uuid.ParseBytes(messageId); err == nil {
+			wm.messageId = string(messageId)
+			wm.message = message
+			wm.async = true
+		}
 	}
 
 	return wm
@@ -70,9 +80,16 @@ func (my *Message) GetAsync() bool { return my.async }
 func (my *Message) GetMessageId() string { return my.messageId }
 
 // GetMessage 获取消息
-func (my *Message) GetMessage() []byte {
-	return operation.Ternary(my.async, my.message, my.prototypeMessage)
-}
+func (my *Message) GetMessage() []byte { return my.message }
 
 // GetPrototypeMessage 获取原始消息
 func (my *Message) GetPrototypeMessage() []byte { return my.prototypeMessage }
+
+// GetEnvelope 获取消息信封：旧格式消息返回由其转换的信封（版本号为0）
+func (my *Message) GetEnvelope() *Envelope {
+	if my.envelope != nil {
+		return my.envelope
+	}
+
+	return &Envelope{Id: my.messageId, Type: EnvelopeEvent, Headers: map[string]string{}, Payload: my.GetMessage()}
+}
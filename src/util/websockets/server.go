@@ -2,6 +2,7 @@ package websockets
 
 import (
 	"fmt"
+	"sync"
 
 	"errors"
 
@@ -15,6 +16,23 @@ type (
 		closeChan          chan struct{}
 		receiveMessageChan chan []byte
 		status             WebsocketConnStatus
+		statusMu           sync.RWMutex
+		writeMu            sync.Mutex
+		queue              serverQueue
+	}
+
+	// serverQueue 发送队列：在独立协程中按顺序发送，调用方不会被慢连接阻塞
+	serverQueue struct {
+		messages []serverQueueMessage
+		running  bool
+		mu       sync.Mutex
+	}
+
+	// serverQueueMessage 发送队列中的消息及其回调
+	serverQueueMessage struct {
+		prototypeMessage []byte
+		onSuccess        serverSendMessageSuccessFn
+		onFail           serverSendMessageFailFn
 	}
 
 	ServerReceiveMessage struct {
@@ -24,6 +42,8 @@ type (
 
 var ServerApp Server
 
+const serverQueueSize = 1024 // 发送队列长度
+
 func (*Server) New(conn *websocket.Conn) *Server { return NewServer(conn) }
 
 // NewServer 实例话：websocket服务端
@@ -41,12 +61,28 @@ func NewServer(conn *websocket.Conn) *Server {
 
 // IsOnline 是否在线
 func (my *Server) IsOnline() bool {
-	return my.status == Online
+	return my.getStatus() == Online
 }
 
 // IsOffline 是否离线
 func (my *Server) IsOffline() bool {
-	return my.status == Offline
+	return my.getStatus() == Offline
+}
+
+// getStatus 获取连接状态：发送队列的协程与读取协程会同时访问
+func (my *Server) getStatus() WebsocketConnStatus {
+	my.statusMu.RLock()
+	defer my.statusMu.RUnlock()
+
+	return my.status
+}
+
+// setStatus 设置连接状态
+func (my *Server) setStatus(status WebsocketConnStatus) {
+	my.statusMu.Lock()
+	defer my.statusMu.Unlock()
+
+	my.status = status
 }
 
 // Conn 获取链接
@@ -62,7 +98,7 @@ func (my *Server) SyncMessage(prototypeMessage []byte, onSuccess serverSendMessa
 	}
 
 	message := NewMessage(false, prototypeMessage)
-	if err := my.conn.WriteMessage(websocket.TextMessage, message.GetMessage()); err != nil && onFail != nil {
+	if err := my.write(websocket.TextMessage, message.GetMessage()); err != nil && onFail != nil {
 		onFail(fmt.Errorf("发送失败：%s [%s -> %s] %s", err.Error(), my.addr, message.GetMessage(), message.GetPrototypeMessage()))
 		return
 	}
@@ -79,7 +115,7 @@ func (my *Server) AsyncMessage(prototypeMessage []byte, onSuccess serverSendMess
 	}
 
 	message := NewMessage(true, prototypeMessage)
-	if err := my.conn.WriteMessage(websocket.TextMessage, message.GetMessage()); err != nil && onFail != nil {
+	if err := my.write(websocket.TextMessage, message.GetMessage()); err != nil && onFail != nil {
 		onFail(fmt.Errorf("发送失败：%s [%s -> %s] %s", err.Error(), my.addr, message.GetMessage(), message.GetPrototypeMessage()))
 		return
 	}
@@ -88,9 +124,70 @@ func (my *Server) AsyncMessage(prototypeMessage []byte, onSuccess serverSendMess
 	}
 }
 
-// Close 关闭
+// enqueue 发送消息：异步，进入发送队列后立即返回，队列已满时丢弃并执行失败回调
+func (my *Server) enqueue(prototypeMessage []byte, onSuccess serverSendMessageSuccessFn, onFail serverSendMessageFailFn) {
+	my.queue.mu.Lock()
+	if len(my.queue.messages) >= serverQueueSize {
+		my.queue.mu.Unlock()
+		if onFail != nil {
+			onFail(fmt.Errorf("发送失败：发送队列已满：%s -> %s", my.addr, prototypeMessage))
+		}
+		return
+	}
+
+	my.queue.messages = append(my.queue.messages, serverQueueMessage{prototypeMessage: prototypeMessage, onSuccess: onSuccess, onFail: onFail})
+	if !my.queue.running {
+		my.queue.running = true
+		go my.drain()
+	}
+	my.queue.mu.Unlock()
+}
+
+// drain 按顺序发送队列中的消息并执行各自的回调，队列为空时结束
+func (my *Server) drain() {
+	for {
+		my.queue.mu.Lock()
+		if len(my.queue.messages) == 0 {
+			my.queue.messages, my.queue.running = nil, false
+			my.queue.mu.Unlock()
+			return
+		}
+		message := my.queue.messages[0]
+		my.queue.messages = my.queue.messages[1:]
+		my.queue.mu.Unlock()
+
+		my.AsyncMessage(message.prototypeMessage, message.onSuccess, message.onFail)
+	}
+}
+
+// SendEnvelope 发送消息信封：消息类型由编解码器决定
+func (my *Server) SendEnvelope(envelope *Envelope, codec EnvelopeCodec) error {
+	if my.IsOffline() {
+		return WebsocketOfflineErr.New(my.addr)
+	}
+
+	data, err := codec.Encode(envelope)
+	if err != nil {
+		return err
+	}
+
+	return my.write(codec.MessageType(), data)
+}
+
+// write 写入消息：同一连接不支持并发写入，广播与回复可能同时发生，需加锁
+func (my *Server) write(messageType int, data []byte) error {
+	my.writeMu.Lock()
+	defer my.writeMu.Unlock()
+
+	return my.conn.WriteMessage(messageType, data)
+}
+
+// Close 关闭：重复关闭不会阻塞
 func (my *Server) Close() *Server {
-	my.closeChan <- struct{}{}
+	select {
+	case my.closeChan <- struct{}{}:
+	default:
+	}
 	return my
 }
 
@@ -105,6 +202,8 @@ func (my *Server) Boot(
 		return errors.New("解析消息函数不能为空：onReceiveMessageSuccess")
 	}
 
+	my.setStatus(Online) // 启动后即可发送消息
+
 	go func(
 		onReceiveMessageSuccess serverReceiveMessageSuccessFn,
 		onReceiveMessageFail serverReceiveMessageFailFn,
@@ -112,13 +211,11 @@ func (my *Server) Boot(
 		onCloseCallback serverCloseCallbackFn,
 	) {
 		defer my.conn.Close() // 确保 goroutine 结束时关闭连接
-		my.status = Online
 
 		for {
 			select {
 			case <-my.closeChan:
-				close(my.closeChan)
-				my.status = Offline
+				my.setStatus(Offline)
 				if onCloseCallback != nil {
 					onCloseCallback(my.conn)
 				}
@@ -126,29 +223,26 @@ func (my *Server) Boot(
 			default:
 				messageType, prototypeMessage, err := my.conn.ReadMessage()
 				if err != nil {
-					if err.Error() == "websocket: close 1006 (abnormal closure): unexpected EOF" {
-						close(my.closeChan)
-						my.status = Offline
-						if onCloseCallback != nil {
-							onCloseCallback(my.conn)
-						}
-						return
-					}
-					if onReceiveMessageFail != nil {
+					// 读取错误后连接不可再用：非关闭帧的错误先回调，随后统一按关闭处理，以便清理连接池与房间
+					var closeErr *websocket.CloseError
+					if !errors.As(err, &closeErr) && onReceiveMessageFail != nil {
 						onReceiveMessageFail(my.conn, err)
 					}
-					break
+					my.setStatus(Offline)
+					if onCloseCallback != nil {
+						onCloseCallback(my.conn)
+					}
+					return
 				}
 
 				switch messageType {
-				case websocket.TextMessage:
+				case websocket.TextMessage, websocket.BinaryMessage:
 					message := ParseMessage(prototypeMessage)
 					go onReceiveMessageSuccess(my, message)
-				case websocket.BinaryMessage:
 				case websocket.CloseMessage:
 					return
 				case websocket.PingMessage:
-					if err = my.conn.WriteMessage(websocket.TextMessage, []byte{}); err != nil {
+					if err = my.write(websocket.TextMessage, []byte{}); err != nil {
 						if onSendMessageFail != nil {
 							onSendMessageFail(fmt.Errorf("发送消息失败(pong)：%s", my.conn.RemoteAddr().String()))
 						}
@@ -15,6 +15,12 @@ type (
 	ServerPool struct {
 		connections             *dict.AnyDict[string, *Server]
 		addrToAuth              *dict.AnyDict[string, string]
+		rooms                   *roomRegistry
+		router                  *Router
+		routerMu                sync.RWMutex
+		broker                  Broker
+		brokerMu                sync.RWMutex
+		nodeId                  string
 		onConnectionFail        serverConnectionFailFn
 		onConnectionSuccess     serverConnectionSuccessFn
 		onSendMessageSuccess    serverSendMessageSuccessFn
@@ -44,6 +50,7 @@ func OnceServer(serverCallbackConfig ServerCallbackConfig) *ServerPool {
 		serverPool = &ServerPool{
 			connections:             dict.Make[string, *Server](),
 			addrToAuth:              dict.Make[string, string](),
+			rooms:                   newRoomRegistry(),
 			onConnectionFail:        serverCallbackConfig.OnConnectionFail,
 			onConnectionSuccess:     serverCallbackConfig.OnConnectionSuccess,
 			onSendMessageSuccess:    serverCallbackConfig.OnSendMessageSuccess,
@@ -72,12 +79,24 @@ func (*ServerPool) removeConn(addr *string) {
 	serverPool.connections.RemoveByKey(*addr)
 }
 
+// closeCallback 关闭时回调：清理连接及其所在房间后执行用户回调
+func (*ServerPool) closeCallback(server *Server) serverCloseCallbackFn {
+	return func(conn *websocket.Conn) {
+		serverPool.rooms.leaveAll(server.addr)
+		serverPool.removeConn(&server.addr)
+
+		if serverPool.onCloseCallback != nil {
+			serverPool.onCloseCallback(conn)
+		}
+	}
+}
+
 // SendMsgByAddr 发送消息：通过地址
 func (my *ServerPool) SendMsgByAddr(addr *string, propMsg []byte) {
 	serverPool.SendMessageByAddr(addr, propMsg)
 }
 
-// SendMessageByAddr 发送消息：通过地址
+// SendMessageByAddr 发送消息：通过地址，仅本实例（地址只在连接所在的实例上有意义，不会转发到其他实例）
 func (*ServerPool) SendMessageByAddr(addr *string, prototypeMessage []byte) {
 	if server, ok := serverPool.connections.Get(*addr); ok {
 		server.AsyncMessage(prototypeMessage, serverPool.onSendMessageSuccess, serverPool.onSendMessageFail)
@@ -90,14 +109,31 @@ func (*ServerPool) SendMessageByAddr(addr *string, prototypeMessage []byte) {
 
 // SendMsgByAuthId 发送消息：通过认证ID
 func (my *ServerPool) SendMsgByAuthId(authId *string, propMsg []byte) {
-	my.SendMsgByAddr(authId, propMsg)
+	my.SendMessageByAuthId(authId, propMsg)
 }
 
-// SendMessageByAuthId 发送消息：通过认证ID
+// SendMessageByAuthId 发送消息：通过认证ID，设置了消息代理时同时转发到其他实例
 func (*ServerPool) SendMessageByAuthId(authId *string, prototypeMessage []byte) {
-	serverPool.connections.GetValuesByKeys(serverPool.addrToAuth.GetKeysByValues(*authId).ToSlice()...).Each(func(idx int, server *Server) {
+	serverPool.sendLocalByAuthId(*authId, prototypeMessage)
+	serverPool.publish(BrokerMessage{Kind: BrokerToAuthId, Target: *authId, Payload: prototypeMessage})
+}
+
+// sendLocalByAuthId 发送消息：通过认证ID，仅本实例
+func (*ServerPool) sendLocalByAuthId(authId string, prototypeMessage []byte) {
+	for _, server := range serverPool.localServersByAuthId(authId) {
 		server.AsyncMessage(prototypeMessage, serverPool.onSendMessageSuccess, serverPool.onSendMessageFail)
+	}
+}
+
+// localServersByAuthId 获取本实例中认证ID对应的连接
+func (*ServerPool) localServersByAuthId(authId string) (servers []*Server) {
+	serverPool.connections.GetValuesByKeys(serverPool.addrToAuth.GetKeysByValues(authId).ToSlice()...).Each(func(idx int, server *Server) {
+		if server != nil { // 本实例没有该认证ID的连接时为空
+			servers = append(servers, server)
+		}
 	})
+
+	return
 }
 
 func (my *ServerPool) SetOnConnSuc(fn serverConnectionSuccessFn) *ServerPool {
@@ -220,10 +256,10 @@ func (*ServerPool) Handle(
 
 	// 开启接收消息
 	if err = server.Boot(
-		serverPool.onReceiveMessageSuccess,
+		serverPool.receiveCallback(),
 		serverPool.onReceiveMessageFail,
 		serverPool.onSendMessageFail,
-		serverPool.onCloseCallback,
+		serverPool.closeCallback(server),
 	); err != nil {
 		if serverPool.onConnectionFail != nil {
 			serverPool.onConnectionFail(err)
@@ -0,0 +1,166 @@
+package websockets
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// roomRegistry 房间注册表：房间 => 连接地址 => 连接，并维护连接地址 => 房间的反向索引用于断开时清理
+type roomRegistry struct {
+	rooms   map[string]map[string]*Server
+	members map[string]map[string]struct{}
+	mu      sync.RWMutex
+}
+
+func newRoomRegistry() *roomRegistry {
+	return &roomRegistry{rooms: map[string]map[string]*Server{}, members: map[string]map[string]struct{}{}}
+}
+
+// join 加入房间
+func (my *roomRegistry) join(room string, server *Server) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	if _, ok := my.rooms[room]; !ok {
+		my.rooms[room] = map[string]*Server{}
+	}
+	my.rooms[room][server.addr] = server
+
+	if _, ok := my.members[server.addr]; !ok {
+		my.members[server.addr] = map[string]struct{}{}
+	}
+	my.members[server.addr][room] = struct{}{}
+}
+
+// leave 离开房间：房间为空时删除
+func (my *roomRegistry) leave(room, addr string) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.remove(room, addr)
+}
+
+// leaveAll 离开全部房间
+func (my *roomRegistry) leaveAll(addr string) {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	for room := range my.members[addr] {
+		my.remove(room, addr)
+	}
+}
+
+// remove 移除成员：调用方需持有锁
+func (my *roomRegistry) remove(room, addr string) {
+	if servers, ok := my.rooms[room]; ok {
+		delete(servers, addr)
+		if len(servers) == 0 {
+			delete(my.rooms, room)
+		}
+	}
+
+	if rooms, ok := my.members[addr]; ok {
+		delete(rooms, room)
+		if len(rooms) == 0 {
+			delete(my.members, addr)
+		}
+	}
+}
+
+// servers 获取房间内的连接
+func (my *roomRegistry) servers(room string) []*Server {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	return slices.Collect(maps.Values(my.rooms[room]))
+}
+
+// JoinRoom 加入房间
+func (*ServerPool) JoinRoom(room string, server *Server) *ServerPool {
+	if room != "" && server != nil {
+		serverPool.rooms.join(room, server)
+	}
+
+	return serverPool
+}
+
+// LeaveRoom 离开房间
+func (*ServerPool) LeaveRoom(room string, server *Server) *ServerPool {
+	if server != nil {
+		serverPool.rooms.leave(room, server.addr)
+	}
+
+	return serverPool
+}
+
+// LeaveAllRooms 离开全部房间：连接关闭时自动调用
+func (*ServerPool) LeaveAllRooms(server *Server) *ServerPool {
+	if server != nil {
+		serverPool.rooms.leaveAll(server.addr)
+	}
+
+	return serverPool
+}
+
+// InRoom 是否在房间中
+func (*ServerPool) InRoom(room string, server *Server) bool {
+	serverPool.rooms.mu.RLock()
+	defer serverPool.rooms.mu.RUnlock()
+
+	_, ok := serverPool.rooms.rooms[room][server.addr]
+
+	return ok
+}
+
+// GetRooms 获取全部房间名称
+func (*ServerPool) GetRooms() []string {
+	serverPool.rooms.mu.RLock()
+	defer serverPool.rooms.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(serverPool.rooms.rooms))
+}
+
+// GetRoomsByServer 获取连接所在的房间名称
+func (*ServerPool) GetRoomsByServer(server *Server) []string {
+	serverPool.rooms.mu.RLock()
+	defer serverPool.rooms.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(serverPool.rooms.members[server.addr]))
+}
+
+// GetRoomMembers 获取房间内的连接
+func (*ServerPool) GetRoomMembers(room string) []*Server { return serverPool.rooms.servers(room) }
+
+// GetRoomSize 获取房间内的连接数量
+func (*ServerPool) GetRoomSize(room string) int {
+	serverPool.rooms.mu.RLock()
+	defer serverPool.rooms.mu.RUnlock()
+
+	return len(serverPool.rooms.rooms[room])
+}
+
+// BroadcastToRoom 发送消息：房间广播，exclude不为空时跳过该连接（通常为发送者），设置了消息代理时同时转发到其他实例
+func (*ServerPool) BroadcastToRoom(room string, prototypeMessage []byte, exclude *Server) {
+	count := serverPool.broadcastLocal(room, prototypeMessage, exclude)
+	if serverPool.publish(BrokerMessage{Kind: BrokerToRoom, Target: room, Payload: prototypeMessage}) {
+		return
+	}
+
+	if count == 0 && serverPool.onSendMessageFail != nil {
+		serverPool.onSendMessageFail(fmt.Errorf("房间不存在或为空：%s", room))
+	}
+}
+
+// broadcastLocal 发送消息：房间广播，仅本实例，返回房间内的连接数量
+func (*ServerPool) broadcastLocal(room string, prototypeMessage []byte, exclude *Server) int {
+	servers := serverPool.rooms.servers(room)
+	for _, server := range servers {
+		if server != exclude {
+			server.AsyncMessage(prototypeMessage, serverPool.onSendMessageSuccess, serverPool.onSendMessageFail)
+		}
+	}
+
+	return len(servers)
+}
@@ -0,0 +1,152 @@
+package websockets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestServerPool 启动本地websocket服务：消息格式 join:房间、leave:房间、say:房间:内容
+func newTestServerPool(t *testing.T) (*ServerPool, string) {
+	t.Helper()
+
+	pool := OnceServer(ServerCallbackConfig{}).
+		SetOnReceiveMessageSuccess(func(server *Server, message Message) {
+			command, body, _ := strings.Cut(string(message.GetMessage()), ":")
+			switch command {
+			case "join":
+				serverPool.JoinRoom(body, server)
+			case "leave":
+				serverPool.LeaveRoom(body, server)
+			case "say":
+				room, content, _ := strings.Cut(body, ":")
+				serverPool.BroadcastToRoom(room, []byte(content), server)
+			}
+			_ = server.SendEnvelope(EnvelopeApp.New(EnvelopeReply, []byte("ok")), JsonEnvelopeCodec)
+		})
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pool.Handle(w, r, nil, func(http.Header) (string, error) { return r.URL.Query().Get("id"), nil })
+	}))
+	t.Cleanup(httpServer.Close)
+
+	return pool, "ws" + strings.TrimPrefix(httpServer.URL, "http")
+}
+
+// dialTestClient 连接测试服务
+func dialTestClient(t *testing.T, addr, id string) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(addr+"?id="+id, nil)
+	if err != nil {
+		t.Fatalf("连接失败：%v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// request 发送消息并等待服务端确认
+func request(t *testing.T, conn *websocket.Conn, message string) {
+	t.Helper()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		t.Fatalf("发送失败：%v", err)
+	}
+	if received := readTestMessage(t, conn); string(received.GetMessage()) != "ok" {
+		t.Fatalf("期望确认消息：%s", received.GetMessage())
+	}
+}
+
+// readTestMessage 读取一条消息
+func readTestMessage(t *testing.T, conn *websocket.Conn) Message {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("读取失败：%v", err)
+	}
+
+	return ParseMessage(data)
+}
+
+// waitFor 等待条件满足
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+	t.Fatal("等待超时")
+}
+
+// TestRoom1Broadcast 测试：房间广播与成员查询
+func TestRoom1Broadcast(t *testing.T) {
+	pool, addr := newTestServerPool(t)
+
+	var (
+		alice = dialTestClient(t, addr, "alice")
+		bob   = dialTestClient(t, addr, "bob")
+		carol = dialTestClient(t, addr, "carol")
+	)
+
+	request(t, alice, "join:dashboard")
+	request(t, bob, "join:dashboard")
+	request(t, bob, "join:alerts")
+	request(t, carol, "join:alerts")
+
+	t.Run("成员查询", func(t *testing.T) {
+		if size := pool.GetRoomSize("dashboard"); size != 2 {
+			t.Errorf("房间人数错误：%d", size)
+		}
+		if rooms := pool.GetRooms(); !slices.Contains(rooms, "dashboard") || !slices.Contains(rooms, "alerts") {
+			t.Errorf("房间列表错误：%v", rooms)
+		}
+		for _, server := range pool.GetRoomMembers("alerts") {
+			if !pool.InRoom("alerts", server) {
+				t.Errorf("成员不在房间中：%s", server.addr)
+			}
+			if rooms := pool.GetRoomsByServer(server); !slices.Contains(rooms, "alerts") {
+				t.Errorf("连接所在房间错误：%v", rooms)
+			}
+		}
+	})
+
+	t.Run("广播排除发送者", func(t *testing.T) {
+		if err := alice.WriteMessage(websocket.TextMessage, []byte("say:dashboard:cpu:90%")); err != nil {
+			t.Fatalf("发送失败：%v", err)
+		}
+
+		if message := readTestMessage(t, bob); !message.GetAsync() || string(message.GetMessage()) != "cpu:90%" {
+			t.Errorf("广播消息错误：%s", message.GetMessage())
+		}
+		if message := readTestMessage(t, alice); string(message.GetMessage()) != "ok" {
+			t.Errorf("发送者不应收到广播：%s", message.GetMessage())
+		}
+	})
+
+	t.Run("离开房间", func(t *testing.T) {
+		request(t, carol, "leave:alerts")
+		if size := pool.GetRoomSize("alerts"); size != 1 {
+			t.Errorf("房间人数错误：%d", size)
+		}
+	})
+
+	t.Run("断开连接自动清理", func(t *testing.T) {
+		_ = bob.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		_ = bob.Close()
+
+		waitFor(t, func() bool { return pool.GetRoomSize("dashboard") == 1 && pool.GetRoomSize("alerts") == 0 })
+		if slices.Contains(pool.GetRooms(), "alerts") {
+			t.Errorf("空房间未删除：%v", pool.GetRooms())
+		}
+	})
+}
@@ -0,0 +1,312 @@
+package websockets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type (
+	// RpcContext 请求上下文
+	RpcContext struct {
+		server   *Server
+		envelope *Envelope
+		method   string
+		authId   string
+		values   map[string]any
+		mu       sync.RWMutex
+	}
+
+	// RpcHandler 请求处理函数：返回回复的消息体，返回错误时回复错误信封
+	RpcHandler func(ctx *RpcContext) ([]byte, error)
+
+	// RpcMiddleware 中间件
+	RpcMiddleware func(next RpcHandler) RpcHandler
+
+	// Router 请求路由：按信封消息头中的方法名分发请求信封，回复自动关联请求的消息编号
+	Router struct {
+		routes      map[string]rpcRoute
+		middlewares []RpcMiddleware
+		codec       EnvelopeCodec
+		mu          sync.RWMutex
+	}
+
+	rpcRoute struct {
+		handler     RpcHandler
+		middlewares []RpcMiddleware
+	}
+)
+
+// EnvelopeHeaderMethod 请求信封中方法名所在的消息头
+const EnvelopeHeaderMethod = "method"
+
+var RouterApp Router
+
+// New 实例化：请求路由，默认使用与请求相同的编解码器回复
+func (*Router) New() *Router { return &Router{routes: map[string]rpcRoute{}} }
+
+// SetCodec 设置回复使用的编解码器
+func (my *Router) SetCodec(codec EnvelopeCodec) *Router {
+	my.codec = codec
+
+	return my
+}
+
+// Use 添加全局中间件：按添加顺序由外到内执行
+func (my *Router) Use(middlewares ...RpcMiddleware) *Router {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.middlewares = append(my.middlewares, middlewares...)
+
+	return my
+}
+
+// Handle 注册方法：middlewares为该方法独有的中间件，在全局中间件之后执行
+func (my *Router) Handle(method string, handler RpcHandler, middlewares ...RpcMiddleware) *Router {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.routes[method] = rpcRoute{handler: handler, middlewares: middlewares}
+
+	return my
+}
+
+// HasMethod 是否已注册方法
+func (my *Router) HasMethod(method string) bool {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	_, ok := my.routes[method]
+
+	return ok
+}
+
+// Dispatch 分发消息：非请求信封返回false，交由普通消息回调处理；处理函数panic时回复RpcPanic错误
+func (my *Router) Dispatch(server *Server, message Message) bool {
+	envelope := message.envelope
+	if envelope == nil || envelope.Type != EnvelopeRequest {
+		return false
+	}
+
+	ctx := &RpcContext{server: server, envelope: envelope, method: envelope.GetHeader(EnvelopeHeaderMethod), values: map[string]any{}}
+	if serverPool != nil {
+		ctx.authId, _ = serverPool.addrToAuth.Get(server.addr)
+	}
+
+	handler, ok := my.handler(ctx.method)
+
+	var (
+		payload []byte
+		err     error
+	)
+	if ok {
+		payload, err = invoke(handler, ctx)
+	} else {
+		err = RpcMethodNotFoundErr.New(ctx.method)
+	}
+
+	reply := envelope.Reply(payload)
+	if err != nil {
+		reply = envelope.ReplyError(err)
+	}
+	reply.SetHeader(EnvelopeHeaderMethod, ctx.method)
+
+	codec := my.codec
+	if codec == nil {
+		codec = codecOf(message.GetPrototypeMessage())
+	}
+
+	if err = server.SendEnvelope(reply, codec); err != nil && serverPool != nil && serverPool.onSendMessageFail != nil {
+		serverPool.onSendMessageFail(fmt.Errorf("发送回复失败：%s [%s] %w", server.addr, ctx.method, err))
+	}
+
+	return true
+}
+
+// invoke 执行处理链：消息回调运行在独立协程中，panic未被捕获会导致进程退出
+func invoke(handler RpcHandler, ctx *RpcContext) (payload []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			payload, err = nil, RpcPanicErr.New(fmt.Sprintf("%v", r))
+		}
+	}()
+
+	return handler(ctx)
+}
+
+// handler 组装方法的处理链：全局中间件在外，方法中间件在内
+func (my *Router) handler(method string) (RpcHandler, bool) {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	route, ok := my.routes[method]
+	if !ok {
+		return nil, false
+	}
+
+	var (
+		handler     = route.handler
+		middlewares = append(append([]RpcMiddleware{}, my.middlewares...), route.middlewares...)
+	)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler, true
+}
+
+// HandleJson 注册方法：请求与回复的消息体均为json
+func HandleJson[Req, Res any](router *Router, method string, handler func(ctx *RpcContext, request Req) (Res, error), middlewares ...RpcMiddleware) *Router {
+	return router.Handle(method, func(ctx *RpcContext) ([]byte, error) {
+		var request Req
+		if payload := ctx.envelope.Payload; len(payload) > 0 {
+			if err := json.Unmarshal(payload, &request); err != nil {
+				return nil, EnvelopeDecodeErr.Wrap(err)
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(response)
+		if err != nil {
+			return nil, EnvelopeEncodeErr.Wrap(err)
+		}
+
+		return data, nil
+	}, middlewares...)
+}
+
+// RpcRecovery 中间件：捕获处理函数中的panic并回复错误（Dispatch已兜底捕获，用于在其他中间件之前处理panic）
+func RpcRecovery() RpcMiddleware {
+	return func(next RpcHandler) RpcHandler {
+		return func(ctx *RpcContext) (payload []byte, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					payload, err = nil, RpcPanicErr.New(fmt.Sprintf("%v", r))
+				}
+			}()
+
+			return next(ctx)
+		}
+	}
+}
+
+// RpcLogger 中间件：记录方法、耗时与错误，printf为空时使用log.Printf
+func RpcLogger(printf func(format string, args ...any)) RpcMiddleware {
+	if printf == nil {
+		printf = log.Printf
+	}
+
+	return func(next RpcHandler) RpcHandler {
+		return func(ctx *RpcContext) ([]byte, error) {
+			start := time.Now()
+			payload, err := next(ctx)
+			if err != nil {
+				printf("[rpc] %s %s %s 失败：%v", ctx.server.addr, ctx.method, time.Since(start), err)
+			} else {
+				printf("[rpc] %s %s %s", ctx.server.addr, ctx.method, time.Since(start))
+			}
+
+			return payload, err
+		}
+	}
+}
+
+// RpcAuth 中间件：校验失败时回复未授权错误
+func RpcAuth(check func(ctx *RpcContext) error) RpcMiddleware {
+	return func(next RpcHandler) RpcHandler {
+		return func(ctx *RpcContext) ([]byte, error) {
+			if err := check(ctx); err != nil {
+				return nil, RpcUnauthorizedErr.Wrap(err)
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// GetServer 获取连接
+func (my *RpcContext) GetServer() *Server { return my.server }
+
+// GetEnvelope 获取请求信封
+func (my *RpcContext) GetEnvelope() *Envelope { return my.envelope }
+
+// GetMethod 获取方法名
+func (my *RpcContext) GetMethod() string { return my.method }
+
+// GetAuthId 获取连接的认证ID
+func (my *RpcContext) GetAuthId() string { return my.authId }
+
+// GetPayload 获取请求消息体
+func (my *RpcContext) GetPayload() []byte { return my.envelope.Payload }
+
+// Set 设置上下文值：用于中间件向处理函数传递数据
+func (my *RpcContext) Set(key string, value any) *RpcContext {
+	my.mu.Lock()
+	defer my.mu.Unlock()
+
+	my.values[key] = value
+
+	return my
+}
+
+// Get 获取上下文值
+func (my *RpcContext) Get(key string) (any, bool) {
+	my.mu.RLock()
+	defer my.mu.RUnlock()
+
+	value, ok := my.values[key]
+
+	return value, ok
+}
+
+// codecOf 根据原始消息判断编解码器
+func codecOf(prototypeMessage []byte) EnvelopeCodec {
+	if bytes.HasPrefix(prototypeMessage, binaryEnvelopeMagic) {
+		return BinaryEnvelopeCodec
+	}
+
+	return JsonEnvelopeCodec
+}
+
+// SetRouter 设置请求路由：请求信封交由路由处理，其余消息仍由接收消息回调处理，对已建立的连接同样生效
+func (*ServerPool) SetRouter(router *Router) *ServerPool {
+	serverPool.routerMu.Lock()
+	defer serverPool.routerMu.Unlock()
+
+	serverPool.router = router
+
+	return serverPool
+}
+
+// getRouter 获取请求路由
+func (*ServerPool) getRouter() *Router {
+	serverPool.routerMu.RLock()
+	defer serverPool.routerMu.RUnlock()
+
+	return serverPool.router
+}
+
+// receiveCallback 接收消息回调：每条消息到达时获取当前的请求路由，优先交由请求路由处理
+func (*ServerPool) receiveCallback() serverReceiveMessageSuccessFn {
+	if serverPool.getRouter() == nil && serverPool.onReceiveMessageSuccess == nil {
+		return nil // 保持连接启动时对接收消息回调的校验
+	}
+
+	return func(server *Server, message Message) {
+		if router := serverPool.getRouter(); router != nil && router.Dispatch(server, message) {
+			return
+		}
+
+		if serverPool.onReceiveMessageSuccess != nil {
+			serverPool.onReceiveMessageSuccess(server, message)
+		}
+	}
+}
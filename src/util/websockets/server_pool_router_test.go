@@ -0,0 +1,149 @@
+package websockets
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// call 发送请求信封并读取回复
+func call(t *testing.T, conn *websocket.Conn, codec EnvelopeCodec, method string, payload []byte) (*Envelope, *Envelope) {
+	t.Helper()
+
+	request := EnvelopeApp.New(EnvelopeRequest, payload).SetHeader(EnvelopeHeaderMethod, method)
+	data, err := request.Encode(codec)
+	if err != nil {
+		t.Fatalf("编码失败：%v", err)
+	}
+	if err = conn.WriteMessage(codec.MessageType(), data); err != nil {
+		t.Fatalf("发送失败：%v", err)
+	}
+
+	message := readTestMessage(t, conn)
+
+	return request, message.GetEnvelope()
+}
+
+// TestRouter1Dispatch 测试：请求路由
+func TestRouter1Dispatch(t *testing.T) {
+	type (
+		addRequest  struct{ A, B int }
+		addResponse struct{ Sum int }
+	)
+
+	var (
+		logs   []string
+		logsMu sync.Mutex
+		router = RouterApp.New().
+			Use(RpcLogger(func(format string, args ...any) {
+				logsMu.Lock()
+				defer logsMu.Unlock()
+				logs = append(logs, format)
+			}), RpcRecovery())
+	)
+
+	HandleJson(router, "math.add", func(ctx *RpcContext, request addRequest) (addResponse, error) {
+		return addResponse{Sum: request.A + request.B}, nil
+	})
+	router.
+		Handle("echo", func(ctx *RpcContext) ([]byte, error) { return ctx.GetPayload(), nil }).
+		Handle("whoami", func(ctx *RpcContext) ([]byte, error) {
+			role, _ := ctx.Get("role")
+			return []byte(ctx.GetAuthId() + ":" + role.(string)), nil
+		}, RpcAuth(func(ctx *RpcContext) error {
+			if ctx.GetAuthId() != "admin" {
+				return errors.New("需要管理员")
+			}
+			ctx.Set("role", "root")
+			return nil
+		})).
+		Handle("boom", func(ctx *RpcContext) ([]byte, error) { panic("爆炸") })
+
+	pool, addr := newTestServerPool(t)
+	pool.SetRouter(router)
+
+	var (
+		admin = dialTestClient(t, addr, "admin")
+		guest = dialTestClient(t, addr, "guest")
+	)
+
+	t.Run("json请求与回复关联", func(t *testing.T) {
+		request, reply := call(t, admin, JsonEnvelopeCodec, "math.add", []byte(`{"A":1,"B":2}`))
+		if reply.Type != EnvelopeReply || reply.ReplyTo != request.Id || string(reply.Payload) != `{"Sum":3}` {
+			t.Errorf("回复错误：%+v %s", reply, reply.Payload)
+		}
+	})
+
+	t.Run("二进制请求使用二进制回复", func(t *testing.T) {
+		payload := []byte{0x00, 0x01, ':', 0xff}
+		request, reply := call(t, admin, BinaryEnvelopeCodec, "echo", payload)
+		if reply.Version != EnvelopeVersion || reply.ReplyTo != request.Id || string(reply.Payload) != string(payload) {
+			t.Errorf("回复错误：%+v", reply)
+		}
+	})
+
+	t.Run("方法中间件：认证", func(t *testing.T) {
+		if _, reply := call(t, admin, JsonEnvelopeCodec, "whoami", nil); string(reply.Payload) != "admin:root" {
+			t.Errorf("回复错误：%s", reply.Payload)
+		}
+		if _, reply := call(t, guest, JsonEnvelopeCodec, "whoami", nil); reply.Type != EnvelopeError || !strings.Contains(string(reply.Payload), "需要管理员") {
+			t.Errorf("期望未授权错误：%s %s", reply.Type, reply.Payload)
+		}
+	})
+
+	t.Run("错误回复", func(t *testing.T) {
+		if _, reply := call(t, admin, JsonEnvelopeCodec, "nope", nil); reply.Type != EnvelopeError || !strings.Contains(string(reply.Payload), "nope") {
+			t.Errorf("期望方法不存在错误：%s %s", reply.Type, reply.Payload)
+		}
+		if _, reply := call(t, admin, JsonEnvelopeCodec, "boom", nil); reply.Type != EnvelopeError || !strings.Contains(string(reply.Payload), "爆炸") {
+			t.Errorf("期望panic错误：%s %s", reply.Type, reply.Payload)
+		}
+		if _, reply := call(t, admin, JsonEnvelopeCodec, "math.add", []byte("{")); reply.Type != EnvelopeError {
+			t.Errorf("期望解码错误：%s %s", reply.Type, reply.Payload)
+		}
+	})
+
+	t.Run("非请求消息交由普通回调", func(t *testing.T) {
+		request(t, admin, "join:rpc")
+	})
+
+	t.Run("日志中间件", func(t *testing.T) {
+		logsMu.Lock()
+		defer logsMu.Unlock()
+		if len(logs) != 6 {
+			t.Errorf("日志条数错误：%d", len(logs))
+		}
+	})
+}
+
+// TestRouter2Late 测试：连接建立后设置路由、未使用恢复中间件时捕获panic
+func TestRouter2Late(t *testing.T) {
+	pool, addr := newTestServerPool(t)
+	pool.SetRouter(nil)
+	t.Cleanup(func() { pool.SetRouter(nil) })
+
+	conn := dialTestClient(t, addr, "early")
+	request(t, conn, "join:late")
+
+	pool.SetRouter(RouterApp.New().
+		Handle("echo", func(ctx *RpcContext) ([]byte, error) { return ctx.GetPayload(), nil }).
+		Handle("boom", func(ctx *RpcContext) ([]byte, error) { panic("爆炸") }))
+
+	t.Run("已建立的连接使用新路由", func(t *testing.T) {
+		if _, reply := call(t, conn, JsonEnvelopeCodec, "echo", []byte("hi")); reply.Type != EnvelopeReply || string(reply.Payload) != "hi" {
+			t.Errorf("回复错误：%s %s", reply.Type, reply.Payload)
+		}
+	})
+
+	t.Run("未使用恢复中间件时回复panic错误", func(t *testing.T) {
+		if _, reply := call(t, conn, JsonEnvelopeCodec, "boom", nil); reply.Type != EnvelopeError || !strings.Contains(string(reply.Payload), "爆炸") {
+			t.Errorf("期望panic错误：%s %s", reply.Type, reply.Payload)
+		}
+		if _, reply := call(t, conn, JsonEnvelopeCodec, "echo", []byte("alive")); string(reply.Payload) != "alive" {
+			t.Errorf("panic后连接不可用：%s", reply.Payload)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package websockets
 type WebsocketConnStatus string
 
 var (
-	Online  WebsocketConnStatus = "ON-LINE"
-	Offline WebsocketConnStatus = "OFF-LINE"
+	Online       WebsocketConnStatus = "ON-LINE"
+	Offline      WebsocketConnStatus = "OFF-LINE"
+	Reconnecting WebsocketConnStatus = "RECONNECTING"
 )